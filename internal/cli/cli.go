@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultServer   = "http://localhost:8080"
+	maxResponseSize = 10 << 20
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{name: "export", summary: "download quiz or session results as csv, ndjson or xlsx", run: runExport},
+	{name: "generate", summary: "create a quiz from the word bank", run: runGenerate},
+	{name: "import", summary: "create a quiz from a csv, json or anki file", run: runImport},
+	{name: "lint", summary: "check a quiz file or a stored quiz against the content rules", run: runLint},
+}
+
+// Run executes the subcommand named by args[0]
+func Run(args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(os.Stdout)
+		return nil
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:])
+		}
+	}
+
+	usage(os.Stderr)
+	return fmt.Errorf("unknown command: %s", args[0])
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: wordwizardry [command] [flags]")
+	fmt.Fprintln(w, "\nWithout a command the HTTP server is started.\n\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// serverURL reads the server address from the flag, then WORDWIZARDRY_SERVER
+func serverURL(flagValue string) string {
+	if flagValue != "" {
+		return strings.TrimRight(flagValue, "/")
+	}
+	if env := os.Getenv("WORDWIZARDRY_SERVER"); env != "" {
+		return strings.TrimRight(env, "/")
+	}
+	return defaultServer
+}
+
+var httpClient = &http.Client{Timeout: 60 * time.Second}
+
+// checkResponse turns a non-2xx response into an error carrying its body
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
+
+// openOutput returns stdout for an empty path or "-", otherwise creates the file
+func openOutput(path string) (io.WriteCloser, error) {
+	if path == "" || path == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.Create(path)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
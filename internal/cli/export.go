@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+
+	"wordwizardry/internal/pkg/export"
+)
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	quizID := fs.String("quiz", "", "export saved results of every finished session of this quiz")
+	sessionID := fs.String("session", "", "export the answers of this session")
+	formatName := fs.String("format", "csv", "csv, ndjson or xlsx")
+	output := fs.String("o", "", "output file, defaults to stdout")
+	server := fs.String("server", "", "server URL (default $WORDWIZARDRY_SERVER or "+defaultServer+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if (*quizID == "") == (*sessionID == "") {
+		return errors.New("exactly one of -quiz or -session is required")
+	}
+
+	format, err := export.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	path := "/api/quiz/" + url.PathEscape(*quizID) + "/export"
+	if *sessionID != "" {
+		path = "/api/sessions/" + url.PathEscape(*sessionID) + "/export"
+	}
+
+	resp, err := httpClient.Get(serverURL(*server) + path + "?format=" + url.QueryEscape(string(format)))
+	if err != nil {
+		return fmt.Errorf("failed to request export: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	out, err := openOutput(*output)
+	if err != nil {
+		return fmt.Errorf("failed to open output: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+func runGenerate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	title := fs.String("title", "", "quiz title (default named after the filter)")
+	quizID := fs.String("id", "", "quiz ID (default generated)")
+	tag := fs.String("tag", "", "only ask about words with this tag")
+	level := fs.String("level", "", "only ask about words of this CEFR level")
+	pos := fs.String("pos", "", "only ask about words of this part of speech")
+	count := fs.Int("count", 10, "number of questions")
+	options := fs.Int("options", 4, "options per question")
+	seed := fs.Int64("seed", 0, "seed to reproduce a quiz (default random)")
+	dryRun := fs.Bool("dry-run", false, "print the questions without creating the quiz")
+	server := fs.String("server", "", "server URL (default $WORDWIZARDRY_SERVER or "+defaultServer+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"quiz_id":        *quizID,
+		"title":          *title,
+		"tag":            *tag,
+		"cefr_level":     *level,
+		"part_of_speech": *pos,
+		"count":          *count,
+		"options":        *options,
+		"seed":           *seed,
+		"dry_run":        *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Post(serverURL(*server)+"/api/quiz/generate", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to generate quiz: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	var generated struct {
+		Seed      int64             `json:"seed"`
+		Quiz      models.Quiz       `json:"quiz"`
+		Questions []models.Question `json:"questions"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&generated); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if *dryRun {
+		for i, q := range generated.Questions {
+			fmt.Printf("%d. %s: %s\n", i+1, q.Word, q.Meaning)
+			for _, option := range q.Options {
+				mark := " "
+				if option == q.Correct {
+					mark = "*"
+				}
+				fmt.Printf("   %s %s\n", mark, option)
+			}
+		}
+		fmt.Printf("seed %d\n", generated.Seed)
+		return nil
+	}
+
+	fmt.Printf("generated %q as %s with %d questions (seed %d)\n",
+		generated.Quiz.Title, generated.Quiz.ID, len(generated.Questions), generated.Seed)
+	return nil
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"wordwizardry/internal/pkg/quizimport"
+)
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := fs.String("file", "", "quiz file to import")
+	formatName := fs.String("format", "", "csv, json or anki (default inferred from the file extension)")
+	title := fs.String("title", "", "quiz title, required for csv and anki files")
+	quizID := fs.String("id", "", "quiz ID (default generated)")
+	dryRun := fs.Bool("dry-run", false, "only parse and validate the file locally")
+	server := fs.String("server", "", "server URL (default $WORDWIZARDRY_SERVER or "+defaultServer+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return errors.New("-file is required")
+	}
+
+	format, err := importFormat(*formatName, *file)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return fmt.Errorf("failed to open quiz file: %w", err)
+	}
+	defer f.Close()
+
+	if *dryRun {
+		parsed, err := quizimport.Parse(f, format, quizimport.Options{QuizID: *quizID, Title: *title})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("ok: %q with %d questions\n", parsed.Quiz.Title, len(parsed.Questions))
+		return nil
+	}
+
+	query := url.Values{}
+	query.Set("format", string(format))
+	query.Set("title", *title)
+	query.Set("quiz_id", *quizID)
+
+	resp, err := httpClient.Post(serverURL(*server)+"/api/quiz/import?"+query.Encode(), "application/octet-stream", f)
+	if err != nil {
+		return fmt.Errorf("failed to upload quiz: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	var imported struct {
+		Quiz struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"quiz"`
+		Questions []json.RawMessage `json:"questions"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&imported); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	fmt.Printf("imported %q as %s with %d questions\n", imported.Quiz.Title, imported.Quiz.ID, len(imported.Questions))
+	return nil
+}
+
+func importFormat(name, file string) (quizimport.Format, error) {
+	if name != "" {
+		return quizimport.ParseFormat(name)
+	}
+	return quizimport.FormatFromFilename(file)
+}
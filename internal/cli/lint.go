@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+
+	"wordwizardry/internal/pkg/quizimport"
+	"wordwizardry/internal/pkg/quizlint"
+)
+
+func runLint(args []string) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	file := fs.String("file", "", "quiz file to lint locally")
+	formatName := fs.String("format", "", "csv, json or anki (default inferred from the file extension)")
+	title := fs.String("title", "", "quiz title, for csv and anki files")
+	quizID := fs.String("quiz", "", "lint a quiz stored on the server instead of a file")
+	version := fs.Int("version", 0, "quiz version to lint with -quiz (default latest)")
+	listRules := fs.Bool("rules", false, "list the rules and exit")
+	server := fs.String("server", "", "server URL (default $WORDWIZARDRY_SERVER or "+defaultServer+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *listRules {
+		for _, rule := range quizlint.Rules {
+			fmt.Printf("%-22s %-8s %s\n", rule.Name, rule.Severity, rule.Description)
+		}
+		return nil
+	}
+
+	if (*file == "") == (*quizID == "") {
+		return errors.New("exactly one of -file or -quiz is required")
+	}
+
+	var (
+		issues []quizlint.Issue
+		locate func(quizlint.Issue) string
+		err    error
+	)
+	if *file != "" {
+		issues, locate, err = lintFile(*file, *formatName, *title)
+	} else {
+		issues, err = lintRemote(serverURL(*server), *quizID, *version)
+		locate = func(issue quizlint.Issue) string { return issue.QuestionID }
+	}
+	if err != nil {
+		return err
+	}
+
+	errorCount := 0
+	for _, issue := range issues {
+		if issue.Severity == quizlint.SeverityError {
+			errorCount++
+		}
+		if where := locate(issue); where != "" {
+			fmt.Printf("%s [%s] %s: %s\n", where, issue.Severity, issue.Rule, issue.Message)
+			continue
+		}
+		fmt.Printf("[%s] %s: %s\n", issue.Severity, issue.Rule, issue.Message)
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("%d of %d issues are errors", errorCount, len(issues))
+	}
+	if len(issues) == 0 {
+		fmt.Println("ok: no issues")
+	}
+	return nil
+}
+
+// lintFile decodes the file without validating it and lints the result, so
+// every issue is reported against the line its question came from
+func lintFile(file, formatName, title string) ([]quizlint.Issue, func(quizlint.Issue) string, error) {
+	format, err := importFormat(formatName, file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open quiz file: %w", err)
+	}
+	defer f.Close()
+
+	decoded, err := quizimport.Decode(f, format, quizimport.Options{Title: title})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	locate := func(issue quizlint.Issue) string {
+		if line := decoded.Lines[issue.QuestionID]; line > 0 {
+			return "line " + strconv.Itoa(line)
+		}
+		return ""
+	}
+
+	return quizlint.Lint(decoded.Quiz, decoded.Questions).Issues, locate, nil
+}
+
+func lintRemote(server, quizID string, version int) ([]quizlint.Issue, error) {
+	query := url.Values{}
+	if version > 0 {
+		query.Set("version", strconv.Itoa(version))
+	}
+
+	resp, err := httpClient.Get(server + "/api/quiz/" + url.PathEscape(quizID) + "/lint?" + query.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to lint quiz: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var report struct {
+		Issues []quizlint.Issue `json:"issues"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return report.Issues, nil
+}
@@ -0,0 +1,139 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+	FormatXLSX   Format = "xlsx"
+)
+
+// ParseFormat validates a format name, an empty name means CSV
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case "":
+		return FormatCSV, nil
+	case FormatCSV, FormatNDJSON, FormatXLSX:
+		return f, nil
+	}
+	return "", fmt.Errorf("unsupported export format: %s", s)
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
+
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Row is one player's answer to one question
+type Row struct {
+	QuizID        string  `json:"quiz_id"`
+	SessionID     string  `json:"session_id"`
+	PlayerID      string  `json:"player_id"`
+	Player        string  `json:"player"`
+	QuestionID    string  `json:"question_id"`
+	Question      string  `json:"question"`
+	Choice        string  `json:"choice"`
+	CorrectAnswer string  `json:"correct_answer"`
+	Correct       bool    `json:"correct"`
+	Points        int     `json:"points"`
+	ResponseTime  float64 `json:"response_time"`
+}
+
+// columns is the header shared by the tabular formats, in Row field order
+var columns = []string{
+	"quiz_id",
+	"session_id",
+	"player_id",
+	"player",
+	"question_id",
+	"question",
+	"choice",
+	"correct_answer",
+	"correct",
+	"points",
+	"response_time",
+}
+
+// cells renders a row in the order of columns
+func (r Row) cells() []string {
+	return []string{
+		r.QuizID,
+		r.SessionID,
+		r.PlayerID,
+		r.Player,
+		r.QuestionID,
+		r.Question,
+		r.Choice,
+		r.CorrectAnswer,
+		strconv.FormatBool(r.Correct),
+		strconv.Itoa(r.Points),
+		strconv.FormatFloat(r.ResponseTime, 'f', -1, 64),
+	}
+}
+
+// textColumns is how many leading columns hold free text, the rest are
+// written by cells itself
+const textColumns = 8
+
+// sheetCells renders a row for the spreadsheet formats. Text that a
+// spreadsheet would run as a formula gets a leading ' so it shows as typed.
+func (r Row) sheetCells() []string {
+	cells := r.cells()
+	for i := range cells[:textColumns] {
+		if cells[i] != "" && strings.ContainsRune("=+-@\t\r", rune(cells[i][0])) {
+			cells[i] = "'" + cells[i]
+		}
+	}
+	return cells
+}
+
+// RowWriter streams rows to an underlying writer. Close must be called to
+// flush the trailing parts of the format, it does not close the writer.
+type RowWriter interface {
+	WriteRow(row Row) error
+	Close() error
+}
+
+func NewRowWriter(w io.Writer, format Format) (RowWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w)
+	case FormatNDJSON:
+		return newNDJSONWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	}
+	return nil, fmt.Errorf("unsupported export format: %s", format)
+}
+
+// WriteRows streams all rows in the given format
+func WriteRows(w io.Writer, format Format, rows []Row) error {
+	rw, err := NewRowWriter(w, format)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if err := rw.WriteRow(row); err != nil {
+			return err
+		}
+	}
+
+	return rw.Close()
+}
@@ -0,0 +1,160 @@
+package grading
+
+import (
+	"strings"
+	"unicode"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+// defaultCredit is the share of the points for 1, 2, ... edits when a
+// question does not configure its own, by the length of the expected answer.
+// Short words get no slack, a single edit already makes them another word.
+func defaultCredit(length int) []float64 {
+	switch {
+	case length < 4:
+		return nil
+	case length < 8:
+		return []float64{0.5}
+	}
+	return []float64{0.5, 0.25}
+}
+
+// articles are dropped from the start of answers, "the platypus" and
+// "platypus" are the same answer
+var articles = []string{"a ", "an ", "the "}
+
+// accents folds the accented latin letters players leave out or type
+// differently, "café" and "cafe" are the same answer
+var accents = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "æ", "ae",
+	"ç", "c",
+	"è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i",
+	"ñ", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "œ", "oe",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u",
+	"ý", "y", "ÿ", "y",
+	"ß", "ss",
+)
+
+// Normalize brings a typed answer to the form answers are compared in:
+// lower case without accents, punctuation, leading article or extra spaces
+func Normalize(s string) string {
+	s = accents.Replace(strings.ToLower(s))
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\'' || r == '’':
+			// "don't" and "dont" are the same answer
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	s = strings.Join(strings.Fields(b.String()), " ")
+	for _, article := range articles {
+		if rest, ok := strings.CutPrefix(s, article); ok {
+			return rest
+		}
+	}
+	return s
+}
+
+// Grade compares a typed answer with the expected one and decides how much
+// credit it earns. Strict questions are compared as typed and need an exact
+// match.
+func Grade(answer, expected string, cfg *models.GradingConfig) *models.Grading {
+	if cfg == nil {
+		cfg = &models.GradingConfig{}
+	}
+
+	metric := cfg.Metric
+	if metric == "" {
+		metric = models.EditDamerau
+	}
+
+	grading := &models.Grading{
+		Metric:     metric,
+		Normalized: strings.TrimSpace(answer),
+		Expected:   strings.TrimSpace(expected),
+	}
+
+	if cfg.Strict {
+		if grading.Normalized == grading.Expected {
+			grading.Credit = 1
+		} else {
+			grading.Distance = Distance(metric, grading.Normalized, grading.Expected)
+		}
+		return grading
+	}
+
+	grading.Normalized = Normalize(answer)
+	grading.Expected = Normalize(expected)
+	grading.Distance = Distance(metric, grading.Normalized, grading.Expected)
+
+	credit := cfg.Credit
+	if credit == nil {
+		credit = defaultCredit(len([]rune(grading.Expected)))
+	}
+
+	switch {
+	case grading.Distance == 0:
+		grading.Credit = 1
+	case grading.Distance <= len(credit):
+		grading.Credit = credit[grading.Distance-1]
+	}
+	return grading
+}
+
+// Distance counts the edits between a and b with the given metric
+func Distance(metric models.EditMetric, a, b string) int {
+	if metric == models.EditLevenshtein {
+		return Levenshtein(a, b)
+	}
+	return Damerau(a, b)
+}
+
+// Levenshtein counts the insertions, deletions and substitutions that turn a
+// into b
+func Levenshtein(a, b string) int {
+	return editDistance([]rune(a), []rune(b), false)
+}
+
+// Damerau counts edits like Levenshtein but also takes swapping two adjacent
+// letters as one edit, the most common typo. This is the optimal string
+// alignment variant, no substring is edited twice.
+func Damerau(a, b string) int {
+	return editDistance([]rune(a), []rune(b), true)
+}
+
+func editDistance(a, b []rune, transpositions bool) int {
+	// Three rows are enough, transpositions look two back
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if transpositions && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(b)]
+}
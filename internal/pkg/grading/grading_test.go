@@ -0,0 +1,90 @@
+package grading
+
+import (
+	"testing"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Platypus", "platypus"},
+		{"  the   Platypus ", "platypus"},
+		{"An egg", "egg"},
+		{"Café", "cafe"},
+		{"don't", "dont"},
+		{"well-known!", "well known"},
+		{"theory", "theory"},
+		{"Straße", "strasse"},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b        string
+		levenshtein int
+		damerau     int
+	}{
+		{"", "", 0, 0},
+		{"cat", "", 3, 3},
+		{"kitten", "sitting", 3, 3},
+		{"recieve", "receive", 2, 1},
+		{"ca", "abc", 3, 3},
+		{"naïve", "naive", 1, 1},
+	}
+
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.levenshtein {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.levenshtein)
+		}
+		if got := Damerau(tt.a, tt.b); got != tt.damerau {
+			t.Errorf("Damerau(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.damerau)
+		}
+		if got := Damerau(tt.b, tt.a); got != tt.damerau {
+			t.Errorf("Damerau(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.damerau)
+		}
+	}
+}
+
+func TestGrade(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   string
+		expected string
+		cfg      *models.GradingConfig
+		distance int
+		credit   float64
+	}{
+		{"exact", "camouflage", "Camouflage", nil, 0, 1},
+		{"article and case", "The Camouflage", "camouflage", nil, 0, 1},
+		{"swapped letters count once", "camoulfage", "camouflage", nil, 1, 0.5},
+		{"two edits on a long word", "camoflag", "camouflage", nil, 2, 0.25},
+		{"too far", "camel", "camouflage", nil, 6, 0},
+		{"short words need to be exact", "cot", "cat", nil, 1, 0},
+		{"medium words allow one edit", "hibernat", "hibernate", nil, 1, 0.25 + 0.25},
+		{"configured credit", "camoulfage", "camouflage", &models.GradingConfig{Credit: []float64{0.75}}, 1, 0.75},
+		{"no typos allowed", "camoulfage", "camouflage", &models.GradingConfig{Credit: []float64{}}, 1, 0},
+		{"levenshtein counts a swap twice", "camoulfage", "camouflage", &models.GradingConfig{Metric: models.EditLevenshtein}, 2, 0.25},
+		{"strict compares as typed", "camouflage", "Camouflage", &models.GradingConfig{Strict: true}, 1, 0},
+		{"strict exact", " Camouflage ", "Camouflage", &models.GradingConfig{Strict: true}, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Grade(tt.answer, tt.expected, tt.cfg)
+			if got.Distance != tt.distance || got.Credit != tt.credit {
+				t.Errorf("Grade(%q, %q) = distance %d credit %v, want distance %d credit %v",
+					tt.answer, tt.expected, got.Distance, got.Credit, tt.distance, tt.credit)
+			}
+		})
+	}
+}
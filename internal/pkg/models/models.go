@@ -1,21 +1,40 @@
 package models
 
 import (
+	"slices"
+	"strings"
 	"time"
 )
 
 type QuizStatus string
 
 const (
-	QuizStatusActive   QuizStatus = "active"
-	QuizStatusInActive QuizStatus = "inactive"
+	QuizStatusDraft     QuizStatus = "draft"
+	QuizStatusInReview  QuizStatus = "in_review"
+	QuizStatusPublished QuizStatus = "published"
+	QuizStatusArchived  QuizStatus = "archived"
 )
 
+// quizStatusTransitions lists the statuses each status may move to
+var quizStatusTransitions = map[QuizStatus][]QuizStatus{
+	QuizStatusDraft:     {QuizStatusInReview},
+	QuizStatusInReview:  {QuizStatusDraft, QuizStatusPublished},
+	QuizStatusPublished: {QuizStatusArchived},
+	QuizStatusArchived:  {QuizStatusDraft},
+}
+
 // Add validation method
 func (s QuizStatus) IsValid() bool {
-	switch s {
-	case QuizStatusActive, QuizStatusInActive:
-		return true
+	_, ok := quizStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether the workflow allows moving to next
+func (s QuizStatus) CanTransitionTo(next QuizStatus) bool {
+	for _, allowed := range quizStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
 	}
 	return false
 }
@@ -27,27 +46,326 @@ func (s QuizStatus) String() string {
 
 type Quiz struct {
 	ID        string     `json:"id"`
+	Version   int        `json:"version"`
 	Title     string     `json:"title"`
 	Status    QuizStatus `json:"status"`
 	CreatedAt time.Time  `json:"created_at"`
+	TimeLimit int        `json:"time_limit,omitempty"` // seconds per question unless the question sets its own
+
+	Scoring *ScoringConfig `json:"scoring,omitempty"` // linear decay when nil
+
+	// Every player gets their own order, so neighbours can't copy by position
+	ShuffleQuestions bool `json:"shuffle_questions,omitempty"` // not in hosted sessions, they move on together
+	ShuffleOptions   bool `json:"shuffle_options,omitempty"`
+
+	Pool *QuestionPool `json:"pool,omitempty"` // every session asks all questions when nil
+}
+
+// QuestionPool makes every session of a quiz draw its own questions from
+// the quiz's questions, e.g. 10 of 50 with at least 3 hard ones
+type QuestionPool struct {
+	Draw  int        `json:"draw"` // questions per session
+	Rules []PoolRule `json:"rules,omitempty"`
+}
+
+// PoolRule limits how many drawn questions match a difficulty and/or a tag.
+// Zero limits are not enforced.
+type PoolRule struct {
+	Difficulty Difficulty `json:"difficulty,omitempty"`
+	Tag        string     `json:"tag,omitempty"`
+	Min        int        `json:"min,omitempty"`
+	Max        int        `json:"max,omitempty"`
+}
+
+// Matches reports whether the question counts towards the rule
+func (r PoolRule) Matches(q Question) bool {
+	if r.Difficulty != "" && q.Difficulty != r.Difficulty {
+		return false
+	}
+	if r.Tag != "" && !slices.Contains(q.Tags, r.Tag) {
+		return false
+	}
+	return true
+}
+
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyMedium Difficulty = "medium"
+	DifficultyHard   Difficulty = "hard"
+)
+
+func (d Difficulty) IsValid() bool {
+	return d == DifficultyEasy || d == DifficultyMedium || d == DifficultyHard
+}
+
+type ScoringStrategy string
+
+const (
+	ScoringLinear      ScoringStrategy = "linear"      // points fall linearly after the perfect time
+	ScoringExponential ScoringStrategy = "exponential" // points fall faster right after the perfect time
+	ScoringFlat        ScoringStrategy = "flat"        // every correct answer is worth the same
+)
+
+func (s ScoringStrategy) IsValid() bool {
+	switch s {
+	case ScoringLinear, ScoringExponential, ScoringFlat:
+		return true
+	}
+	return false
+}
+
+// ScoringConfig picks how a quiz's answers are scored. The strategy scores
+// a correct answer, the other settings add to it and are off when zero.
+type ScoringConfig struct {
+	Strategy          ScoringStrategy `json:"strategy,omitempty"`            // defaults to ScoringLinear
+	StreakBonus       float64         `json:"streak_bonus,omitempty"`        // extra share of the points per earlier correct answer in a row
+	MaxStreak         int             `json:"max_streak,omitempty"`          // longest streak that still raises the bonus
+	FirstCorrectBonus int             `json:"first_correct_bonus,omitempty"` // points for the first correct answer to a question
+	WrongPenalty      int             `json:"wrong_penalty,omitempty"`       // points taken for a wrong answer
+	// DifficultyWeighted scales the points of a correct answer by the rating
+	// of the question when the session was created, from half for the
+	// easiest to double for the hardest
+	DifficultyWeighted bool `json:"difficulty_weighted,omitempty"`
+}
+
+// QuizVersion is an immutable snapshot of a quiz and its questions. Every
+// write to a quiz creates a new version, sessions keep playing the version
+// they were started from.
+type QuizVersion struct {
+	Quiz      Quiz       `json:"quiz"`
+	Questions []Question `json:"questions"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	// The content of a version never changes, its status moves through the
+	// review workflow and every move is kept here
+	StatusHistory []QuizStatusChange `json:"status_history,omitempty"`
+}
+
+type QuizStatusChange struct {
+	From    QuizStatus `json:"from"`
+	To      QuizStatus `json:"to"`
+	Actor   string     `json:"actor"`
+	Comment string     `json:"comment,omitempty"`
+	At      time.Time  `json:"at"`
+}
+
+type QuizScheduleState string
+
+const (
+	QuizScheduleStateScheduled QuizScheduleState = "scheduled" // before opens_at
+	QuizScheduleStateOpen      QuizScheduleState = "open"      // players can join
+	QuizScheduleStateClosed    QuizScheduleState = "closed"    // after closes_at
+)
+
+// QuizSchedule limits when a quiz can be played. A quiz without a schedule is
+// always open. With StartsAt set players join the session the scheduler
+// creates at that time instead of starting their own.
+type QuizSchedule struct {
+	QuizID   string     `json:"quiz_id"`
+	OpensAt  time.Time  `json:"opens_at"`
+	ClosesAt *time.Time `json:"closes_at,omitempty"` // open-ended when nil
+	StartsAt *time.Time `json:"starts_at,omitempty"` // scheduled live start
+
+	State     QuizScheduleState `json:"state"`
+	SessionID string            `json:"session_id,omitempty"` // created at StartsAt
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type QuestionType string
+
+const (
+	QuestionMultipleChoice QuestionType = "multiple_choice" // pick the option that fits the word
+	QuestionTrueFalse      QuestionType = "true_false"      // does Meaning describe Word, Correct is "true" or "false"
+	QuestionFillBlank      QuestionType = "fill_blank"      // type the word missing from Sentence
+	QuestionSpelling       QuestionType = "spelling"        // type the word from its meaning
+	QuestionSynonym        QuestionType = "synonym"         // pick the option that means the same as Word
+	QuestionAntonym        QuestionType = "antonym"         // pick the option that means the opposite of Word
+	QuestionMatchPairs     QuestionType = "match_pairs"     // match every word of Pairs to its meaning
+)
+
+// BlankMarker marks where the word is missing from a fill-in-the-blank sentence
+const BlankMarker = "___"
+
+func (t QuestionType) IsValid() bool {
+	switch t {
+	case QuestionMultipleChoice, QuestionTrueFalse, QuestionFillBlank, QuestionSpelling,
+		QuestionSynonym, QuestionAntonym, QuestionMatchPairs:
+		return true
+	}
+	return false
+}
+
+// HasOptions reports whether players pick the answer from Options
+func (t QuestionType) HasOptions() bool {
+	return t == QuestionMultipleChoice || t == QuestionSynonym || t == QuestionAntonym
+}
+
+// Typed reports whether players type the answer
+func (t QuestionType) Typed() bool {
+	return t == QuestionFillBlank || t == QuestionSpelling
 }
 
 type Question struct {
-	ID      string   `json:"id"`
-	QuizID  string   `json:"quiz_id"`
-	Word    string   `json:"word"`
-	Meaning string   `json:"meaning"`
-	Options []string `json:"options"`
-	Correct string   `json:"correct"`
+	ID      string       `json:"id"`
+	QuizID  string       `json:"quiz_id"`
+	Type    QuestionType `json:"type,omitempty"`    // QuestionMultipleChoice when empty
+	WordID  string       `json:"word_id,omitempty"` // word bank entry the question asks about
+	Word    string       `json:"word"`
+	Meaning string       `json:"meaning"`
+	Options []string     `json:"options"`
+	Correct string       `json:"correct"` // typed questions fall back to Word
+
+	Sentence string      `json:"sentence,omitempty"` // fill_blank, with BlankMarker where the word goes
+	Pairs    []MatchPair `json:"pairs,omitempty"`    // match_pairs
+
+	Grading *GradingConfig `json:"grading,omitempty"` // typed questions, lenient defaults when nil
+
+	Difficulty Difficulty `json:"difficulty,omitempty"` // for pool rules
+	Tags       []string   `json:"tags,omitempty"`
+
+	TimeLimit int `json:"time_limit,omitempty"` // seconds, overrides the quiz time limit
+
+	Explanation string `json:"explanation,omitempty"` // shown after answering in practice
+}
+
+type EditMetric string
+
+const (
+	EditLevenshtein EditMetric = "levenshtein" // insertions, deletions and substitutions
+	EditDamerau     EditMetric = "damerau"     // also counts swapped adjacent letters as one edit
+)
+
+func (m EditMetric) IsValid() bool {
+	return m == EditLevenshtein || m == EditDamerau
+}
+
+// GradingConfig sets how close a typed answer has to be to earn points
+type GradingConfig struct {
+	Metric EditMetric `json:"metric,omitempty"` // defaults to EditDamerau
+	// Credit is the share of the points for an answer 1, 2, ... edits away,
+	// answers further away get none. When nil it depends on the answer's
+	// length, [0] allows no typos.
+	Credit []float64 `json:"credit,omitempty"`
+	Strict bool      `json:"strict,omitempty"` // compare case, accents, punctuation and articles too
+}
+
+type MatchPair struct {
+	Word    string `json:"word"`
+	Meaning string `json:"meaning"`
+}
+
+// Kind returns the type of the question, questions written before there
+// were types are multiple choice
+func (q Question) Kind() QuestionType {
+	if q.Type == "" {
+		return QuestionMultipleChoice
+	}
+	return q.Type
+}
+
+// ExpectedAnswer is the answer as shown to players once the question closed
+func (q Question) ExpectedAnswer() string {
+	switch {
+	case q.Kind() == QuestionMatchPairs:
+		matches := make(map[string]string, len(q.Pairs))
+		for _, pair := range q.Pairs {
+			matches[pair.Word] = pair.Meaning
+		}
+		return q.FormatMatches(matches)
+	case q.Kind().Typed() && q.Correct == "":
+		return q.Word
+	}
+	return q.Correct
+}
+
+// FormatMatches writes a player's word to meaning matches in the order of
+// the question's pairs, e.g. "cat = feline; dog = canine"
+func (q Question) FormatMatches(matches map[string]string) string {
+	parts := make([]string, 0, len(q.Pairs))
+	for _, pair := range q.Pairs {
+		parts = append(parts, pair.Word+" = "+matches[pair.Word])
+	}
+	return strings.Join(parts, "; ")
+}
+
+type CEFRLevel string
+
+const (
+	CEFRA1 CEFRLevel = "A1"
+	CEFRA2 CEFRLevel = "A2"
+	CEFRB1 CEFRLevel = "B1"
+	CEFRB2 CEFRLevel = "B2"
+	CEFRC1 CEFRLevel = "C1"
+	CEFRC2 CEFRLevel = "C2"
+)
+
+func (l CEFRLevel) IsValid() bool {
+	switch l {
+	case CEFRA1, CEFRA2, CEFRB1, CEFRB2, CEFRC1, CEFRC2:
+		return true
+	}
+	return false
+}
+
+// Word is an entry of the word bank. Questions refer to words by ID, so a
+// word is written once and used by any number of quizzes.
+type Word struct {
+	ID           string    `json:"id"`
+	Lemma        string    `json:"lemma"`
+	PartOfSpeech string    `json:"part_of_speech,omitempty"` // e.g. noun, verb
+	Definitions  []string  `json:"definitions"`
+	Examples     []string  `json:"examples,omitempty"` // sentences using the word
+	Synonyms     []string  `json:"synonyms,omitempty"`
+	Level        CEFRLevel `json:"cefr_level,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ReviewItem is the spaced repetition state of one word for one player. It
+// is updated from every graded answer to a question about the word.
+type ReviewItem struct {
+	PlayerID    string    `json:"player_id"`
+	WordID      string    `json:"word_id"`
+	Ease        float64   `json:"ease"`        // interval multiplier, lower for words the player struggles with
+	Interval    int       `json:"interval"`    // days from the last review to the next
+	Repetitions int       `json:"repetitions"` // successful reviews in a row
+	Lapses      int       `json:"lapses"`      // times the word was forgotten after being learned
+	Quality     int       `json:"quality"`     // of the last answer, 0 to 5
+	ReviewedAt  time.Time `json:"reviewed_at"`
+	DueAt       time.Time `json:"due_at"`
+}
+
+// Rating is an Elo rating of a player or a question. A correct answer moves
+// rating from the question to the player, the more so the less likely it was.
+type Rating struct {
+	Value     float64   `json:"rating"`
+	Answers   int       `json:"answers"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type QuizResult struct {
 	ID             string    `json:"id"`
 	QuizID         string    `json:"quiz_id"`
+	QuizVersion    int       `json:"quiz_version"`
+	SessionID      string    `json:"session_id"`
 	PlayerID       string    `json:"player_id"`
+	Username       string    `json:"username"`
 	FinalScore     int       `json:"final_score"`
 	CompletionTime time.Time `json:"completion_time"`
 	Position       int       `json:"position"`
+
+	Answers []ResultAnswer `json:"answers,omitempty"`
+}
+
+// ResultAnswer is a player's answer to one question, kept with the final
+// result so it outlives the session. Unanswered questions have no choice.
+type ResultAnswer struct {
+	QuestionID string `json:"question_id"`
+	Word       string `json:"word"`
+	Answer
 }
 
 type Player struct {
@@ -55,29 +373,235 @@ type Player struct {
 	Username string `json:"username"`
 }
 
+// Account is a player who signed up. Its ID is the player ID in every
+// session they join signed in, so their games, reviews and rating add up.
+// Guests sign in with the token they got at sign up until they set a
+// password.
+type Account struct {
+	ID           string    `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"-"` // bcrypt, empty for guests
+	Guest        bool      `json:"guest,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
 type SessionPlayer struct {
 	Player
 	QuizID string `json:"quiz_id"`
 	Score  int    `json:"score"`
+	Muted  bool   `json:"muted,omitempty"` // set by the host, cannot chat
+	Ready  bool   `json:"ready,omitempty"` // ready-check in the lobby
+
+	Order *PlayerOrder `json:"order,omitempty"` // nil when the quiz shuffles nothing
+}
+
+// PlayerOrder is the order a player is shown a session's questions and
+// options in. Answers are given as text, so they are checked the same way
+// whatever the order.
+type PlayerOrder struct {
+	Seed      int64            `json:"seed"`                // the orders are drawn from it
+	Questions []int            `json:"questions,omitempty"` // indexes into Session.Questions, nil keeps the quiz order
+	Options   map[string][]int `json:"options,omitempty"`   // question ID -> indexes into its options or pairs
+}
+
+type SessionPhase string
+
+const (
+	SessionPhaseLobby      SessionPhase = "lobby" // hosted sessions wait for the host to start
+	SessionPhaseInProgress SessionPhase = "in_progress"
+	SessionPhasePaused     SessionPhase = "paused"
+	SessionPhaseFinished   SessionPhase = "finished"
+)
+
+type LateJoinPolicy string
+
+const (
+	LateJoinAllow LateJoinPolicy = "allow" // players can join a running session
+	LateJoinDeny  LateJoinPolicy = "deny"  // players can only join in the lobby
+)
+
+// SessionSettings configure the lobby of a hosted session. Zero values
+// disable a limit.
+type SessionSettings struct {
+	MinPlayers   int            `json:"min_players,omitempty"`   // ready players that start the session
+	MaxPlayers   int            `json:"max_players,omitempty"`   // capacity
+	LobbyTimeout int            `json:"lobby_timeout,omitempty"` // seconds after the first join the session starts anyway
+	LateJoin     LateJoinPolicy `json:"late_join,omitempty"`     // defaults to LateJoinAllow
 }
 
 type Session struct {
-	ID        string `json:"id"`
-	Quiz      *Quiz  `json:"quiz"`
+	ID        string       `json:"id"`
+	Quiz      *Quiz        `json:"quiz"`
+	Phase     SessionPhase `json:"phase"`
+	CreatedAt time.Time    `json:"created_at"`
+	JoinCode  string       `json:"join_code,omitempty"` // short code players type to join
+
+	// Set once the session finished, results are completed at FinishedAt
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	EndReason  string     `json:"end_reason,omitempty"`
+
+	// How long the session has been in progress, the lobby and pauses do
+	// not count. RunningSince is set while it is in progress, Ran holds the
+	// seconds it was in progress before.
+	RunningSince *time.Time `json:"running_since,omitempty"`
+	Ran          float64    `json:"ran,omitempty"`
+
+	// Questions of a quiz with a pool were drawn with DrawSeed, the same seed
+	// draws the same questions from the same quiz version
+	DrawSeed int64 `json:"draw_seed,omitempty"`
+
+	// Weights scale the points of each question by ID in sessions of
+	// quizzes scored by difficulty. They are fixed from the question ratings
+	// when the session is created, so every player gets the same ones.
+	Weights map[string]float64 `json:"weights,omitempty"`
+
+	// Practice sessions have a single player and no join code or room. They
+	// are not listed with the quiz's sessions and their results are kept
+	// apart from the competitive ones.
+	Practice bool `json:"practice,omitempty"`
+
+	// Adaptive practice sessions ask AskCount questions one at a time, each
+	// picked from Questions near the player's rating. CurrentQuestion is the
+	// one being asked.
+	Adaptive bool `json:"adaptive,omitempty"`
+	AskCount int  `json:"ask_count,omitempty"`
+
+	// Hosted sessions are run by whoever holds the host key, only its hash
+	// is kept. Their players answer one question at a time.
+	HostName        string `json:"host_name,omitempty"`
+	HostKeyHash     string `json:"host_key_hash,omitempty"`
+	CurrentQuestion int    `json:"current_question"` // index into Questions
+
+	// The current question of a hosted session closes at QuestionDeadline.
+	// While paused there is no deadline and TimeRemaining holds the seconds
+	// that were left.
+	QuestionDeadline *time.Time `json:"question_deadline,omitempty"`
+	TimeRemaining    float64    `json:"time_remaining,omitempty"`
+
+	// Players of other sessions open questions on their own, Opened holds
+	// when each one first did, keyed like Result
+	Opened map[string]time.Time `json:"opened,omitempty"`
+
+	// Kicked holds the IDs of the players the host kicked, they cannot join
+	// again. Players without an account get a new ID every time they join.
+	Kicked []string `json:"kicked,omitempty"`
+
+	Settings SessionSettings `json:"settings"`
+
 	Questions []Question
 	Players   []SessionPlayer
 	Result    Result
 }
 
+// RunTime is how long the session has been in progress at now
+func (s *Session) RunTime(now time.Time) time.Duration {
+	run := time.Duration(s.Ran * float64(time.Second))
+	if s.RunningSince != nil {
+		run += now.Sub(*s.RunningSince)
+	}
+	return run
+}
+
+func (s *Session) Hosted() bool {
+	return s.HostKeyHash != ""
+}
+
 // Result is a map of questionID:PlayerID to the player's answer
 type Result map[string]Answer
 
+// ResultKey builds the Result key for a player's answer to a question
+func ResultKey(questionID, playerID string) string {
+	return questionID + ":" + playerID
+}
+
+// ParseResultKey splits a Result key back into question and player IDs
+func ParseResultKey(key string) (questionID, playerID string) {
+	i := strings.LastIndex(key, ":")
+	if i < 0 {
+		return key, ""
+	}
+	return key[:i], key[i+1:]
+}
+
 type Answer struct {
-	PlayerChoice  string `json:"player_choice"`
-	CorrectAnswer string `json:"correct_answer"`
+	PlayerChoice  string    `json:"player_choice"`
+	CorrectAnswer string    `json:"correct_answer"`
+	Correct       bool      `json:"correct"`
+	Points        int       `json:"points"`
+	AnswerTime    float64   `json:"answer_time"`
+	AnsweredAt    time.Time `json:"answered_at"`
+	TimedOut      bool      `json:"timed_out,omitempty"` // no answer, or an answer after the time limit
+	Timed         bool      `json:"timed,omitempty"`     // AnswerTime was measured by the server
+
+	Breakdown []PointsPart `json:"breakdown,omitempty"` // sums up to Points
+	Grading   *Grading     `json:"grading,omitempty"`   // typed answers
+}
+
+// Grading records how a typed answer was graded, so the decision can be
+// reviewed later
+type Grading struct {
+	Normalized string     `json:"normalized"` // the answer as compared
+	Expected   string     `json:"expected"`   // the expected answer as compared
+	Metric     EditMetric `json:"metric"`
+	Distance   int        `json:"distance"`
+	Credit     float64    `json:"credit"` // share of the points earned, 1 for an exact answer
+}
+
+// PointsPart is one line of an answer's points breakdown
+type PointsPart struct {
+	Rule   string `json:"rule"` // e.g. "correct", "speed", "streak"
+	Points int    `json:"points"`
+}
+
+type SessionEventType string
+
+const (
+	SessionEventCreated         SessionEventType = "session_created"
+	SessionEventPlayerJoined    SessionEventType = "player_joined"
+	SessionEventAnswerSubmitted SessionEventType = "answer_submitted"
+	SessionEventScoreChanged    SessionEventType = "score_changed"
+	SessionEventPhaseChanged    SessionEventType = "phase_changed"
+	SessionEventPlayerRemoved   SessionEventType = "player_removed"
+	SessionEventPlayerMuted     SessionEventType = "player_muted"
+	SessionEventQuestionChanged SessionEventType = "question_changed"
+	SessionEventPlayerReady     SessionEventType = "player_ready"
+	SessionEventQuestionTimer   SessionEventType = "question_timer"
+	SessionEventQuestionClosed  SessionEventType = "question_closed"
+	SessionEventQuestionOpened  SessionEventType = "question_opened"
+)
+
+// RemovedKicked is the reason of a player_removed event when the host
+// kicked the player
+const RemovedKicked = "kicked"
+
+// SessionEvent is a single entry of a session's append-only log. The session
+// state is never stored directly, it is derived by folding these events in
+// order. Only the fields relevant to the event type are set.
+type SessionEvent struct {
+	Seq        int64            `json:"seq"`
+	Type       SessionEventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	PlayerID   string           `json:"player_id,omitempty"`
+	QuestionID string           `json:"question_id,omitempty"`
+
+	Session *Session       `json:"session,omitempty"` // session_created
+	Player  *SessionPlayer `json:"player,omitempty"`  // player_joined
+	Answer  *Answer        `json:"answer,omitempty"`  // answer_submitted
+	Score   int            `json:"score,omitempty"`   // score_changed, as a delta
+	Phase   SessionPhase   `json:"phase,omitempty"`   // phase_changed
+	Reason  string         `json:"reason,omitempty"`  // phase_changed, player_removed
+	Muted   bool           `json:"muted,omitempty"`   // player_muted
+	Ready   bool           `json:"ready,omitempty"`   // player_ready
+
+	QuestionIndex int        `json:"question_index,omitempty"` // question_changed, question_timer, question_closed
+	Deadline      *time.Time `json:"deadline,omitempty"`       // question_timer, nil when stopped
+	Remaining     float64    `json:"remaining,omitempty"`      // question_timer, seconds left when stopped
 }
 
 type WSMessage struct {
+	// Seq is assigned by the room when the message is broadcast, so clients
+	// can resume from the last message they saw. Direct messages have no Seq.
+	Seq  uint64      `json:"seq,omitempty"`
 	Type string      `json:"type"`
 	Data interface{} `json:"data"`
 }
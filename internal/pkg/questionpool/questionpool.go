@@ -0,0 +1,205 @@
+package questionpool
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+var ErrUnsatisfiable = errors.New("pool rules cannot be met")
+
+// Draw picks pool.Draw of the questions meeting the pool's rules. The same
+// seed and questions draw the same set. Drawn questions keep the quiz order.
+//
+// Rules can overlap, e.g. a minimum of hard questions and a maximum of
+// questions tagged verbs, so the draw is solved for every rule at once: a
+// draw is found whenever one exists.
+func Draw(questions []models.Question, pool *models.QuestionPool, seed int64) ([]models.Question, error) {
+	rng := rand.New(rand.NewSource(seed))
+
+	indexes, err := solve(questions, pool, rng.Perm(len(questions)), rng)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Ints(indexes)
+
+	picked := make([]models.Question, 0, len(indexes))
+	for _, i := range indexes {
+		picked = append(picked, questions[i])
+	}
+	return picked, nil
+}
+
+// Check reports whether any draw meets the pool's rules, with the error
+// Draw would return when none does
+func Check(questions []models.Question, pool *models.QuestionPool) error {
+	order := make([]int, len(questions))
+	for i := range order {
+		order[i] = i
+	}
+
+	_, err := solve(questions, pool, order, nil)
+	return err
+}
+
+// group holds the questions that match the same rules, in draw order. Any
+// questions of a group can stand in for each other, so only how many are
+// drawn from each group has to be decided.
+type group struct {
+	matches   []bool // per rule
+	questions []int
+}
+
+// solve decides how many questions to draw from each group with a
+// backtracking search and takes them from the front of the groups. Questions
+// are considered in order, counts are tried in an order shuffled by rng, or
+// from the most down when rng is nil.
+func solve(questions []models.Question, pool *models.QuestionPool, order []int, rng *rand.Rand) ([]int, error) {
+	if pool.Draw > len(questions) {
+		return nil, fmt.Errorf("%w: %d questions to draw from %d", ErrUnsatisfiable, pool.Draw, len(questions))
+	}
+
+	groups := groupQuestions(questions, pool.Rules, order)
+
+	for r, rule := range pool.Rules {
+		matching := 0
+		for _, g := range groups {
+			if g.matches[r] {
+				matching += len(g.questions)
+			}
+		}
+		if matching < rule.Min {
+			return nil, fmt.Errorf("%w: %s needs %d questions, %d match", ErrUnsatisfiable, describe(rule), rule.Min, matching)
+		}
+	}
+
+	// left[g] is how many questions groups g.. hold in total, and
+	// leftMatching[g][r] how many of them match rule r
+	left := make([]int, len(groups)+1)
+	leftMatching := make([][]int, len(groups)+1)
+	leftMatching[len(groups)] = make([]int, len(pool.Rules))
+	for g := len(groups) - 1; g >= 0; g-- {
+		left[g] = left[g+1] + len(groups[g].questions)
+		leftMatching[g] = slices.Clone(leftMatching[g+1])
+		for r := range pool.Rules {
+			if groups[g].matches[r] {
+				leftMatching[g][r] += len(groups[g].questions)
+			}
+		}
+	}
+
+	take := make([]int, len(groups))
+	counts := make([]int, len(pool.Rules))
+
+	var search func(g, drawn int) bool
+	search = func(g, drawn int) bool {
+		if drawn > pool.Draw || drawn+left[g] < pool.Draw {
+			return false
+		}
+		for r, rule := range pool.Rules {
+			if rule.Max > 0 && counts[r] > rule.Max {
+				return false
+			}
+			if counts[r]+leftMatching[g][r] < rule.Min {
+				return false
+			}
+		}
+		if g == len(groups) {
+			return true
+		}
+
+		for _, n := range tries(len(groups[g].questions), pool.Draw-drawn, rng) {
+			take[g] = n
+			for r := range pool.Rules {
+				if groups[g].matches[r] {
+					counts[r] += n
+				}
+			}
+
+			if search(g+1, drawn+n) {
+				return true
+			}
+
+			for r := range pool.Rules {
+				if groups[g].matches[r] {
+					counts[r] -= n
+				}
+			}
+		}
+		return false
+	}
+
+	if !search(0, 0) {
+		return nil, fmt.Errorf("%w: no %d questions meet every rule together", ErrUnsatisfiable, pool.Draw)
+	}
+
+	indexes := make([]int, 0, pool.Draw)
+	for g, n := range take {
+		indexes = append(indexes, groups[g].questions[:n]...)
+	}
+	return indexes, nil
+}
+
+// groupQuestions groups the questions by the rules they match, groups are
+// ordered by their first question in order
+func groupQuestions(questions []models.Question, rules []models.PoolRule, order []int) []*group {
+	var groups []*group
+	byKey := make(map[string]*group)
+
+	for _, i := range order {
+		matches := make([]bool, len(rules))
+		key := make([]byte, len(rules))
+		for r, rule := range rules {
+			matches[r] = rule.Matches(questions[i])
+			key[r] = '0'
+			if matches[r] {
+				key[r] = '1'
+			}
+		}
+
+		g, ok := byKey[string(key)]
+		if !ok {
+			g = &group{matches: matches}
+			byKey[string(key)] = g
+			groups = append(groups, g)
+		}
+		g.questions = append(g.questions, i)
+	}
+
+	return groups
+}
+
+// tries lists the counts to try drawing from a group of size questions when
+// at most limit more are drawn
+func tries(size, limit int, rng *rand.Rand) []int {
+	n := min(size, limit)
+
+	counts := make([]int, 0, n+1)
+	for c := n; c >= 0; c-- {
+		counts = append(counts, c)
+	}
+
+	if rng != nil {
+		rng.Shuffle(len(counts), func(i, j int) {
+			counts[i], counts[j] = counts[j], counts[i]
+		})
+	}
+	return counts
+}
+
+// describe names what a rule counts, e.g. "hard questions tagged verbs"
+func describe(rule models.PoolRule) string {
+	name := "questions"
+	if rule.Difficulty != "" {
+		name = string(rule.Difficulty) + " " + name
+	}
+	if rule.Tag != "" {
+		name += " tagged " + rule.Tag
+	}
+	return name
+}
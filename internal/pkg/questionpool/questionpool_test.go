@@ -0,0 +1,211 @@
+package questionpool
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+func question(id string, difficulty models.Difficulty, tags ...string) models.Question {
+	return models.Question{ID: id, Difficulty: difficulty, Tags: tags}
+}
+
+func TestDraw(t *testing.T) {
+	tests := []struct {
+		name      string
+		questions []models.Question
+		pool      models.QuestionPool
+		wantErr   bool
+	}{
+		{
+			name: "no rules",
+			questions: []models.Question{
+				question("q1", ""), question("q2", ""), question("q3", ""), question("q4", ""),
+			},
+			pool: models.QuestionPool{Draw: 2},
+		},
+		{
+			name: "draws everything",
+			questions: []models.Question{
+				question("q1", ""), question("q2", ""),
+			},
+			pool: models.QuestionPool{Draw: 2},
+		},
+		{
+			// Filling the first minimum with q1 and q2 leaves no room for
+			// the second one, only q3 can count for both
+			name: "overlapping minimums",
+			questions: []models.Question{
+				question("q1", "", "a"), question("q2", "", "a"), question("q3", "", "a", "b"), question("q4", "", "b"),
+			},
+			pool: models.QuestionPool{Draw: 3, Rules: []models.PoolRule{
+				{Tag: "a", Min: 2},
+				{Tag: "b", Min: 2},
+			}},
+		},
+		{
+			name: "minimum and maximum on overlapping rules",
+			questions: []models.Question{
+				question("q1", models.DifficultyHard, "verbs"),
+				question("q2", models.DifficultyHard, "verbs"),
+				question("q3", models.DifficultyHard),
+				question("q4", models.DifficultyEasy, "verbs"),
+				question("q5", models.DifficultyEasy),
+				question("q6", models.DifficultyEasy),
+			},
+			pool: models.QuestionPool{Draw: 4, Rules: []models.PoolRule{
+				{Difficulty: models.DifficultyHard, Min: 2},
+				{Tag: "verbs", Max: 1},
+				{Difficulty: models.DifficultyEasy, Min: 2},
+			}},
+		},
+		{
+			name: "more to draw than questions",
+			questions: []models.Question{
+				question("q1", ""),
+			},
+			pool:    models.QuestionPool{Draw: 2},
+			wantErr: true,
+		},
+		{
+			name: "minimum above matching questions",
+			questions: []models.Question{
+				question("q1", models.DifficultyHard), question("q2", models.DifficultyEasy),
+			},
+			pool: models.QuestionPool{Draw: 2, Rules: []models.PoolRule{
+				{Difficulty: models.DifficultyHard, Min: 2},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "rules that rule each other out",
+			questions: []models.Question{
+				question("q1", models.DifficultyHard, "verbs"),
+				question("q2", models.DifficultyHard, "verbs"),
+				question("q3", models.DifficultyEasy),
+			},
+			pool: models.QuestionPool{Draw: 2, Rules: []models.PoolRule{
+				{Difficulty: models.DifficultyHard, Min: 2},
+				{Tag: "verbs", Max: 1},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "maximums leave too few questions",
+			questions: []models.Question{
+				question("q1", "", "verbs"), question("q2", "", "verbs"), question("q3", ""),
+			},
+			pool: models.QuestionPool{Draw: 3, Rules: []models.PoolRule{
+				{Tag: "verbs", Max: 1},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkErr := Check(tt.questions, &tt.pool)
+			if (checkErr != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", checkErr, tt.wantErr)
+			}
+
+			for seed := int64(0); seed < 200; seed++ {
+				drawn, err := Draw(tt.questions, &tt.pool, seed)
+				if tt.wantErr {
+					if !errors.Is(err, ErrUnsatisfiable) {
+						t.Fatalf("seed %d: Draw() error = %v, want ErrUnsatisfiable", seed, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("seed %d: Draw() error = %v", seed, err)
+				}
+				if err := validDraw(tt.questions, &tt.pool, drawn); err != nil {
+					t.Fatalf("seed %d: %v", seed, err)
+				}
+			}
+		})
+	}
+}
+
+func TestDrawSameSeed(t *testing.T) {
+	questions := make([]models.Question, 0, 20)
+	for i := range 20 {
+		difficulty := models.DifficultyEasy
+		if i%3 == 0 {
+			difficulty = models.DifficultyHard
+		}
+		questions = append(questions, question(fmt.Sprintf("q%d", i), difficulty))
+	}
+	pool := &models.QuestionPool{Draw: 8, Rules: []models.PoolRule{
+		{Difficulty: models.DifficultyHard, Min: 3},
+	}}
+
+	first, err := Draw(questions, pool, 42)
+	if err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+
+	again, err := Draw(questions, pool, 42)
+	if err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+
+	if !slices.Equal(ids(first), ids(again)) {
+		t.Errorf("same seed drew %v and %v", ids(first), ids(again))
+	}
+
+	differs := false
+	for seed := int64(0); seed < 20 && !differs; seed++ {
+		other, err := Draw(questions, pool, seed)
+		if err != nil {
+			t.Fatalf("seed %d: Draw() error = %v", seed, err)
+		}
+		differs = !slices.Equal(ids(first), ids(other))
+	}
+	if !differs {
+		t.Errorf("every seed drew %v", ids(first))
+	}
+}
+
+// validDraw checks the size and rules of a draw, and that it keeps the quiz
+// order without repeating a question
+func validDraw(questions []models.Question, pool *models.QuestionPool, drawn []models.Question) error {
+	if len(drawn) != pool.Draw {
+		return fmt.Errorf("drew %d questions, want %d", len(drawn), pool.Draw)
+	}
+
+	last := -1
+	for _, q := range drawn {
+		i := slices.IndexFunc(questions, func(c models.Question) bool { return c.ID == q.ID })
+		if i <= last {
+			return fmt.Errorf("draw %v is out of quiz order or repeats a question", ids(drawn))
+		}
+		last = i
+	}
+
+	for _, rule := range pool.Rules {
+		n := 0
+		for _, q := range drawn {
+			if rule.Matches(q) {
+				n++
+			}
+		}
+		if n < rule.Min || (rule.Max > 0 && n > rule.Max) {
+			return fmt.Errorf("draw %v has %d %s, want %d to %d", ids(drawn), n, describe(rule), rule.Min, rule.Max)
+		}
+	}
+
+	return nil
+}
+
+func ids(questions []models.Question) []string {
+	ids := make([]string, len(questions))
+	for i, q := range questions {
+		ids[i] = q.ID
+	}
+	return ids
+}
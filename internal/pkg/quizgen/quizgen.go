@@ -0,0 +1,214 @@
+package quizgen
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+const (
+	DefaultCount   = 10
+	DefaultOptions = 4
+)
+
+var (
+	ErrNotEnoughWords = errors.New("not enough words")
+	ErrInvalidOptions = errors.New("invalid generator options")
+)
+
+type Options struct {
+	QuizID  string // generated when empty
+	Title   string
+	Count   int   // questions, DefaultCount when zero
+	Options int   // options per question, DefaultOptions when zero
+	Seed    int64 // the same seed and words give the same quiz, random when zero
+}
+
+// Result is a generated quiz ready to be written. Seed reproduces it.
+type Result struct {
+	Quiz      *models.Quiz      `json:"quiz"`
+	Questions []models.Question `json:"questions"`
+	Seed      int64             `json:"seed"`
+}
+
+// Generate builds a multiple choice quiz asking for the meaning of words
+// picked from targets. Wrong options are the definitions of the words in
+// pool that are most alike the asked word, so they are plausible.
+func Generate(targets, pool []*models.Word, opts Options) (*Result, error) {
+	if opts.Count <= 0 {
+		opts.Count = DefaultCount
+	}
+	if opts.Options <= 0 {
+		opts.Options = DefaultOptions
+	}
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+	if opts.QuizID == "" {
+		opts.QuizID = uuid.New().String()
+	}
+	if opts.Options < 2 {
+		return nil, fmt.Errorf("%w: at least 2 options are required, got %d", ErrInvalidOptions, opts.Options)
+	}
+
+	targets = usable(targets)
+	pool = usable(pool)
+	if len(targets) < opts.Count {
+		return nil, fmt.Errorf("%w: %d questions asked, %d words match", ErrNotEnoughWords, opts.Count, len(targets))
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	rng.Shuffle(len(targets), func(i, j int) { targets[i], targets[j] = targets[j], targets[i] })
+
+	quiz := &models.Quiz{
+		ID:        opts.QuizID,
+		Title:     strings.TrimSpace(opts.Title),
+		CreatedAt: time.Now(),
+	}
+
+	questions := make([]models.Question, 0, opts.Count)
+	for i, word := range targets[:opts.Count] {
+		distractors := pickDistractors(word, pool, opts.Options-1, rng)
+		if len(distractors) < opts.Options-1 {
+			return nil, fmt.Errorf("%w: only %d distractors for %q", ErrNotEnoughWords, len(distractors), word.Lemma)
+		}
+
+		correct := word.Definitions[0]
+		options := append([]string{correct}, distractors...)
+		rng.Shuffle(len(options), func(i, j int) { options[i], options[j] = options[j], options[i] })
+
+		questions = append(questions, models.Question{
+			ID:      fmt.Sprintf("%s_q%d", quiz.ID, i+1),
+			QuizID:  quiz.ID,
+			Type:    models.QuestionMultipleChoice,
+			WordID:  word.ID,
+			Word:    word.Lemma,
+			Meaning: prompt(word),
+			Options: options,
+			Correct: correct,
+		})
+	}
+
+	return &Result{Quiz: quiz, Questions: questions, Seed: opts.Seed}, nil
+}
+
+// usable keeps the words with a definition, in ID order so the result only
+// depends on the seed and not on the order words were listed in
+func usable(words []*models.Word) []*models.Word {
+	kept := make([]*models.Word, 0, len(words))
+	for _, word := range words {
+		if len(word.Definitions) > 0 {
+			kept = append(kept, word)
+		}
+	}
+
+	sort.Slice(kept, func(i, j int) bool { return kept[i].ID < kept[j].ID })
+	return kept
+}
+
+// pickDistractors takes the definitions of the n words most alike word.
+// Words equally alike are taken in random order.
+func pickDistractors(word *models.Word, pool []*models.Word, n int, rng *rand.Rand) []string {
+	type candidate struct {
+		definition string
+		score      int
+		tiebreak   int
+	}
+
+	candidates := make([]candidate, 0, len(pool))
+	seen := map[string]bool{strings.ToLower(word.Definitions[0]): true}
+	for _, other := range pool {
+		definition := other.Definitions[0]
+		if other.ID == word.ID || strings.EqualFold(other.Lemma, word.Lemma) || seen[strings.ToLower(definition)] {
+			continue
+		}
+		seen[strings.ToLower(definition)] = true
+
+		candidates = append(candidates, candidate{
+			definition: definition,
+			score:      similarity(word, other),
+			tiebreak:   rng.Int(),
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].tiebreak < candidates[j].tiebreak
+	})
+
+	distractors := make([]string, 0, n)
+	for _, c := range candidates[:min(n, len(candidates))] {
+		distractors = append(distractors, c.definition)
+	}
+	return distractors
+}
+
+// similarity scores how alike two words are: the same part of speech counts
+// most, then a close level, then every shared tag
+func similarity(a, b *models.Word) int {
+	score := 0
+	if a.PartOfSpeech != "" && a.PartOfSpeech == b.PartOfSpeech {
+		score += 4
+	}
+
+	if a.Level != "" && b.Level != "" {
+		switch distance := levelDistance(a.Level, b.Level); {
+		case distance == 0:
+			score += 2
+		case distance == 1:
+			score++
+		}
+	}
+
+	for _, tag := range a.Tags {
+		if slices.Contains(b.Tags, tag) {
+			score++
+		}
+	}
+	return score
+}
+
+var levels = []models.CEFRLevel{models.CEFRA1, models.CEFRA2, models.CEFRB1, models.CEFRB2, models.CEFRC1, models.CEFRC2}
+
+func levelDistance(a, b models.CEFRLevel) int {
+	d := slices.Index(levels, a) - slices.Index(levels, b)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// prompt is what players read above the options. An example with the word
+// blanked out gives context without naming the word a second time.
+func prompt(word *models.Word) string {
+	if example := BlankExample(word); example != "" {
+		return example
+	}
+
+	if word.PartOfSpeech != "" {
+		return "Pick the meaning of this " + word.PartOfSpeech
+	}
+	return "Pick the meaning of this word"
+}
+
+// BlankExample turns the first example using the word as written into a
+// fill-in-the-blank sentence, empty when no example does
+func BlankExample(word *models.Word) string {
+	for _, example := range word.Examples {
+		lower := strings.ToLower(example)
+		if i := strings.Index(lower, strings.ToLower(word.Lemma)); i >= 0 && len(lower) == len(example) {
+			return example[:i] + models.BlankMarker + example[i+len(word.Lemma):]
+		}
+	}
+	return ""
+}
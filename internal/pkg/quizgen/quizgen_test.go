@@ -0,0 +1,145 @@
+package quizgen
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+func word(id, lemma, definition string, pos string, tags ...string) *models.Word {
+	return &models.Word{ID: id, Lemma: lemma, Definitions: []string{definition}, PartOfSpeech: pos, Tags: tags}
+}
+
+var animals = []*models.Word{
+	word("w1", "burrow", "A hole dug by an animal", "noun", "animals"),
+	word("w2", "hibernate", "To spend the winter asleep", "verb", "animals"),
+	word("w3", "camouflage", "Colouring that hides an animal", "noun", "animals"),
+	word("w4", "migrate", "To move with the seasons", "verb", "animals"),
+	word("w5", "prey", "An animal hunted for food", "noun", "animals"),
+	word("w6", "graze", "To eat grass in a field", "verb", "animals"),
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []*models.Word
+		opts    Options
+		wantErr error
+	}{
+		{"defaults for options", animals, Options{Count: 3}, nil},
+		{"two options", animals, Options{Count: 6, Options: 2}, nil},
+		{"every distractor", animals, Options{Count: 2, Options: 6}, nil},
+		{"more questions than words", animals[:2], Options{Count: 3}, ErrNotEnoughWords},
+		{"more options than words", animals, Options{Count: 1, Options: 7}, ErrNotEnoughWords},
+		{"one option", animals, Options{Count: 1, Options: 1}, ErrInvalidOptions},
+		{"words without definitions", []*models.Word{{ID: "w7", Lemma: "den"}}, Options{Count: 1}, ErrNotEnoughWords},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := int64(1); seed <= 50; seed++ {
+				opts := tt.opts
+				opts.Seed = seed
+
+				result, err := Generate(slices.Clone(tt.targets), slices.Clone(animals), opts)
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("seed %d: Generate() error = %v, want %v", seed, err, tt.wantErr)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("seed %d: Generate() error = %v", seed, err)
+				}
+				if err := validQuiz(result, opts); err != nil {
+					t.Fatalf("seed %d: %v", seed, err)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSameSeed(t *testing.T) {
+	opts := Options{QuizID: "quiz", Count: 4, Seed: 7}
+
+	first, err := Generate(slices.Clone(animals), slices.Clone(animals), opts)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// The order words are listed in must not matter
+	reversed := slices.Clone(animals)
+	slices.Reverse(reversed)
+	again, err := Generate(reversed, slices.Clone(reversed), opts)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for i := range first.Questions {
+		a, b := first.Questions[i], again.Questions[i]
+		if a.WordID != b.WordID || !slices.Equal(a.Options, b.Options) {
+			t.Errorf("question %d: same seed gave %s %v and %s %v", i, a.WordID, a.Options, b.WordID, b.Options)
+		}
+	}
+}
+
+func TestBlankExample(t *testing.T) {
+	tests := []struct {
+		name     string
+		lemma    string
+		examples []string
+		want     string
+	}{
+		{"first matching example", "burrow", []string{"Rabbits dig.", "The burrow is deep."}, "The " + models.BlankMarker + " is deep."},
+		{"any case", "burrow", []string{"Burrow deeper."}, models.BlankMarker + " deeper."},
+		{"no example uses the word", "burrow", []string{"Rabbits dig tunnels."}, ""},
+		{"no examples", "burrow", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &models.Word{Lemma: tt.lemma, Examples: tt.examples}
+			if got := BlankExample(w); got != tt.want {
+				t.Errorf("BlankExample() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// validQuiz checks the number of questions and options, that every question
+// asks a different word and that its options are distinct and hold the
+// correct definition
+func validQuiz(result *Result, opts Options) error {
+	if len(result.Questions) != opts.Count {
+		return fmt.Errorf("generated %d questions, want %d", len(result.Questions), opts.Count)
+	}
+
+	want := opts.Options
+	if want == 0 {
+		want = DefaultOptions
+	}
+
+	asked := make(map[string]bool)
+	for _, q := range result.Questions {
+		if asked[q.WordID] {
+			return fmt.Errorf("%s is asked twice", q.WordID)
+		}
+		asked[q.WordID] = true
+
+		if len(q.Options) != want {
+			return fmt.Errorf("%s has %d options, want %d", q.WordID, len(q.Options), want)
+		}
+		if !slices.Contains(q.Options, q.Correct) {
+			return fmt.Errorf("%s options %v miss %q", q.WordID, q.Options, q.Correct)
+		}
+		sorted := slices.Clone(q.Options)
+		slices.Sort(sorted)
+		if len(slices.Compact(sorted)) != len(q.Options) {
+			return fmt.Errorf("%s options %v repeat", q.WordID, q.Options)
+		}
+	}
+	return nil
+}
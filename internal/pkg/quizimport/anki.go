@@ -0,0 +1,128 @@
+package quizimport
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+)
+
+// maxAnkiOptions is how many options a question built from a deck gets,
+// the correct word plus distractors taken from the other notes
+const maxAnkiOptions = 4
+
+type ankiNote struct {
+	line  int
+	front string
+	back  string
+}
+
+// parseAnki reads notes exported from Anki as plain text: one note per line
+// with the word on the front and its meaning on the back. Lines starting with
+// "#" are Anki file headers, only "#separator:" is honoured. Anki has no
+// distractors, so the options are the words of other notes in the deck.
+func parseAnki(r io.Reader) ([]row, Errors, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	separator := "\t"
+	var (
+		notes []ankiNote
+		errs  Errors
+	)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "#") {
+			if value, ok := strings.CutPrefix(text, "#separator:"); ok {
+				sep, err := ankiSeparator(value)
+				if err != nil {
+					errs = append(errs, LineError{Line: line, Message: err.Error()})
+					continue
+				}
+				separator = sep
+			}
+			continue
+		}
+
+		fields := strings.Split(text, separator)
+		if len(fields) < 2 {
+			errs = append(errs, LineError{Line: line, Message: "expected a front and a back field"})
+			continue
+		}
+
+		notes = append(notes, ankiNote{
+			line:  line,
+			front: strings.TrimSpace(fields[0]),
+			back:  strings.TrimSpace(fields[1]),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read anki deck: %w", err)
+	}
+
+	rows := make([]row, 0, len(notes))
+	for i, note := range notes {
+		rows = append(rows, row{
+			line:    note.line,
+			word:    note.front,
+			meaning: note.back,
+			correct: note.front,
+			options: ankiOptions(notes, i),
+		})
+	}
+
+	return rows, errs, nil
+}
+
+// ankiOptions takes distractors from the notes following note i, wrapping
+// around the deck, and places the correct word at a position derived from
+// the word itself so the same deck always imports the same way.
+func ankiOptions(notes []ankiNote, i int) []string {
+	correct := notes[i].front
+	distractors := make([]string, 0, maxAnkiOptions-1)
+	seen := map[string]bool{correct: true}
+
+	for step := 1; step < len(notes) && len(distractors) < maxAnkiOptions-1; step++ {
+		front := notes[(i+step)%len(notes)].front
+		if front == "" || seen[front] {
+			continue
+		}
+		seen[front] = true
+		distractors = append(distractors, front)
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(correct))
+	pos := int(h.Sum32() % uint32(len(distractors)+1))
+
+	options := make([]string, 0, len(distractors)+1)
+	options = append(options, distractors[:pos]...)
+	options = append(options, correct)
+	options = append(options, distractors[pos:]...)
+	return options
+}
+
+func ankiSeparator(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "tab":
+		return "\t", nil
+	case "comma":
+		return ",", nil
+	case "semicolon":
+		return ";", nil
+	case "pipe":
+		return "|", nil
+	case "space":
+		return " ", nil
+	}
+	if len(value) == 1 {
+		return value, nil
+	}
+	return "", fmt.Errorf("unsupported separator %q", value)
+}
@@ -0,0 +1,107 @@
+package quizimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// parseCSV reads a header row followed by one question per row. Options are
+// either a single "options" column separated by "|" or one column per option
+// (option1, option2, ...). With a "word_id" column the word and meaning come
+// from the word bank when left empty.
+func parseCSV(r io.Reader) ([]row, Errors, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	var optionColumns []int
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if strings.HasPrefix(name, "option") && name != "options" {
+			optionColumns = append(optionColumns, i)
+			continue
+		}
+		columns[name] = i
+	}
+
+	var errs Errors
+	required := []string{"word", "meaning", "correct"}
+	if _, ok := columns["word_id"]; ok {
+		required = []string{"correct"}
+	}
+	for _, required := range required {
+		if _, ok := columns[required]; !ok {
+			errs = append(errs, LineError{Line: 1, Message: fmt.Sprintf("missing %q column", required)})
+		}
+	}
+	if _, ok := columns["options"]; !ok && len(optionColumns) == 0 {
+		errs = append(errs, LineError{Line: 1, Message: `missing "options" or "option1".. columns`})
+	}
+	if len(errs) > 0 {
+		return nil, errs, nil
+	}
+
+	field := func(record []string, i int) string {
+		if i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	// Columns that are optional read as empty when missing
+	column := func(record []string, name string) string {
+		if i, ok := columns[name]; ok {
+			return field(record, i)
+		}
+		return ""
+	}
+
+	var rows []row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				errs = append(errs, LineError{Line: parseErr.Line, Message: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		var options []string
+		if i, ok := columns["options"]; ok {
+			options = strings.Split(field(record, i), "|")
+		}
+		for _, i := range optionColumns {
+			options = append(options, field(record, i))
+		}
+
+		rows = append(rows, row{
+			line:    line,
+			word:    column(record, "word"),
+			meaning: column(record, "meaning"),
+			correct: column(record, "correct"),
+			options: cleanOptions(options),
+			wordID:  column(record, "word_id"),
+		})
+	}
+
+	return rows, errs, nil
+}
@@ -0,0 +1,101 @@
+package quizimport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type jsonQuestion struct {
+	Word    string   `json:"word"`
+	Meaning string   `json:"meaning"`
+	Options []string `json:"options"`
+	Correct string   `json:"correct"`
+	WordID  string   `json:"word_id"`
+}
+
+// parseJSON reads {"title": "...", "questions": [...]}. The questions are
+// decoded one by one so errors can point at the line each one starts on.
+func parseJSON(r io.Reader) (string, []row, Errors, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to read json: %w", err)
+	}
+
+	lineAt := func(offset int64) int {
+		return bytes.Count(data[:offset], []byte("\n")) + 1
+	}
+
+	syntaxErr := func(err error, dec *json.Decoder) Errors {
+		return Errors{{Line: lineAt(dec.InputOffset()), Message: err.Error()}}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return "", nil, Errors{{Line: 1, Message: "expected a JSON object"}}, nil
+	}
+
+	var (
+		title string
+		rows  []row
+		errs  Errors
+	)
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", nil, syntaxErr(err, dec), nil
+		}
+
+		switch key, _ := tok.(string); key {
+		case "title":
+			if err := dec.Decode(&title); err != nil {
+				return "", nil, syntaxErr(err, dec), nil
+			}
+
+		case "questions":
+			if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+				return "", nil, Errors{{Line: lineAt(dec.InputOffset()), Message: `"questions" must be an array`}}, nil
+			}
+
+			for dec.More() {
+				// Skip separators and whitespace so the line is the one the
+				// question object starts on
+				offset := dec.InputOffset()
+				for offset < int64(len(data)) && strings.ContainsRune(", \t\r\n", rune(data[offset])) {
+					offset++
+				}
+
+				var q jsonQuestion
+				if err := dec.Decode(&q); err != nil {
+					errs = append(errs, LineError{Line: lineAt(offset), Message: err.Error()})
+					return "", nil, errs, nil
+				}
+
+				rows = append(rows, row{
+					line:    lineAt(offset),
+					word:    strings.TrimSpace(q.Word),
+					meaning: strings.TrimSpace(q.Meaning),
+					correct: strings.TrimSpace(q.Correct),
+					options: cleanOptions(q.Options),
+					wordID:  strings.TrimSpace(q.WordID),
+				})
+			}
+
+			if _, err := dec.Token(); err != nil {
+				return "", nil, syntaxErr(err, dec), nil
+			}
+
+		default:
+			// Unknown keys are ignored
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return "", nil, syntaxErr(err, dec), nil
+			}
+		}
+	}
+
+	return title, rows, errs, nil
+}
@@ -0,0 +1,210 @@
+package quizimport
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/pkg/quizlint"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatAnki Format = "anki" // tab-separated notes exported from Anki
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatCSV, FormatJSON, FormatAnki:
+		return f, nil
+	case "tsv", "txt":
+		return FormatAnki, nil
+	}
+	return "", fmt.Errorf("unsupported import format: %s", s)
+}
+
+// FormatFromFilename guesses the format from a file extension
+func FormatFromFilename(name string) (Format, error) {
+	ext := strings.TrimPrefix(filepath.Ext(name), ".")
+	if ext == "" {
+		return "", fmt.Errorf("cannot infer import format of %s", name)
+	}
+	return ParseFormat(ext)
+}
+
+type Options struct {
+	QuizID string // generated when empty
+	Title  string // overrides the title found in the file, required for csv and anki
+}
+
+// Result is a parsed quiz ready to be written
+type Result struct {
+	Quiz      *models.Quiz      `json:"quiz"`
+	Questions []models.Question `json:"questions"`
+}
+
+// LineError points at the input line a problem was found on. Problems with
+// the file as a whole have no line.
+type LineError struct {
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e LineError) Error() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// Errors collects every invalid row so they can be fixed in one go
+type Errors []LineError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, le := range e {
+		msgs[i] = le.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// row is a question as read from the input, before IDs are assigned
+type row struct {
+	line    int
+	word    string
+	meaning string
+	options []string
+	correct string
+	wordID  string
+}
+
+// Decoded is a quiz read from a file without content validation, with the
+// line each question was read from
+type Decoded struct {
+	Result
+	Lines map[string]int // question ID -> line
+}
+
+// Decode reads a quiz in the given format and assigns IDs. Only problems
+// that prevent reading the file are reported, the content is not validated.
+func Decode(r io.Reader, format Format, opts Options) (*Decoded, error) {
+	var (
+		title string
+		rows  []row
+		errs  Errors
+		err   error
+	)
+
+	switch format {
+	case FormatCSV:
+		rows, errs, err = parseCSV(r)
+	case FormatJSON:
+		title, rows, errs, err = parseJSON(r)
+	case FormatAnki:
+		rows, errs, err = parseAnki(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if opts.Title != "" {
+		title = opts.Title
+	}
+
+	quizID := opts.QuizID
+	if quizID == "" {
+		quizID = uuid.New().String()
+	}
+
+	decoded := &Decoded{
+		Result: Result{
+			Quiz: &models.Quiz{
+				ID:        quizID,
+				Title:     strings.TrimSpace(title),
+				Status:    models.QuizStatusDraft,
+				CreatedAt: time.Now(),
+			},
+			Questions: make([]models.Question, 0, len(rows)),
+		},
+		Lines: make(map[string]int, len(rows)),
+	}
+
+	for _, rw := range rows {
+		id := uuid.New().String()
+		decoded.Lines[id] = rw.line
+		decoded.Questions = append(decoded.Questions, models.Question{
+			ID:      id,
+			QuizID:  quizID,
+			Word:    rw.word,
+			Meaning: rw.meaning,
+			Options: rw.options,
+			Correct: rw.correct,
+			WordID:  rw.wordID,
+		})
+	}
+
+	return decoded, nil
+}
+
+// Parse decodes a quiz and validates it with the quizlint rules. Every error
+// level issue is returned together as Errors, with the line of the question
+// it was found on.
+func Parse(r io.Reader, format Format, opts Options) (*Result, error) {
+	decoded, err := Decode(r, format, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decoded.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &decoded.Result, nil
+}
+
+// Validate lints the decoded quiz, e.g. once questions referencing the word
+// bank got their text
+func (d *Decoded) Validate() error {
+	report := quizlint.Lint(d.Quiz, d.Questions)
+	if !report.HasErrors() {
+		return nil
+	}
+
+	var errs Errors
+	for _, issue := range report.Errors().Issues {
+		errs = append(errs, d.IssueError(issue))
+	}
+	return errs
+}
+
+// IssueError locates a lint issue in the decoded file
+func (d *Decoded) IssueError(issue quizlint.Issue) LineError {
+	return LineError{
+		Line:    d.Lines[issue.QuestionID],
+		Message: issue.Message,
+	}
+}
+
+// cleanOptions trims options and drops the empty ones
+func cleanOptions(options []string) []string {
+	cleaned := make([]string, 0, len(options))
+	for _, option := range options {
+		if option = strings.TrimSpace(option); option != "" {
+			cleaned = append(cleaned, option)
+		}
+	}
+	return cleaned
+}
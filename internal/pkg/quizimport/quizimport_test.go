@@ -0,0 +1,215 @@
+package quizimport
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		want   Errors
+	}{
+		{
+			name:   "csv missing columns",
+			format: FormatCSV,
+			input:  "word,meaning\nCat,A feline\n",
+			want: Errors{
+				{Line: 1, Message: `missing "correct" column`},
+				{Line: 1, Message: `missing "options" or "option1".. columns`},
+			},
+		},
+		{
+			name:   "csv word_id only requires correct",
+			format: FormatCSV,
+			input:  "word_id\nw1\n",
+			want: Errors{
+				{Line: 1, Message: `missing "correct" column`},
+				{Line: 1, Message: `missing "options" or "option1".. columns`},
+			},
+		},
+		{
+			name:   "csv bad quoting",
+			format: FormatCSV,
+			input:  "word,meaning,correct,options\nCat,A feline,Cat,Cat|Dog\nDog,\"A \"canine,Dog,Cat|Dog\n",
+			want: Errors{
+				{Line: 3, Message: `extraneous or missing " in quoted-field`},
+			},
+		},
+		{
+			name:   "json not an object",
+			format: FormatJSON,
+			input:  "[]",
+			want:   Errors{{Line: 1, Message: "expected a JSON object"}},
+		},
+		{
+			name:   "json questions not an array",
+			format: FormatJSON,
+			input:  "{\n  \"title\": \"Animals\",\n  \"questions\": {}\n}",
+			want:   Errors{{Line: 3, Message: `"questions" must be an array`}},
+		},
+		{
+			name:   "json invalid question",
+			format: FormatJSON,
+			input: `{
+  "questions": [
+    {"word": "Cat", "meaning": "A feline", "correct": "Cat", "options": ["Cat", "Dog"]},
+    {"word": "Dog", "meaning": "A canine", "correct": "Dog", "options": "Cat|Dog"}
+  ]
+}`,
+			want: Errors{{Line: 4, Message: "json: cannot unmarshal string into Go struct field jsonQuestion.options of type []string"}},
+		},
+		{
+			name:   "anki note without back",
+			format: FormatAnki,
+			input:  "#notetype:Basic\nCat\tA feline\nDog\n\nBird\tFlies\n",
+			want:   Errors{{Line: 3, Message: "expected a front and a back field"}},
+		},
+		{
+			name:   "anki unsupported separator",
+			format: FormatAnki,
+			input:  "#separator:colon\nCat\tA feline\n",
+			want:   Errors{{Line: 1, Message: `unsupported separator "colon"`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(tt.input), tt.format, Options{Title: "Animals"})
+
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Decode() error = %v, want %v", err, tt.want)
+			}
+			if !slices.Equal(errs, tt.want) {
+				t.Errorf("Decode() errors = %v, want %v", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		title  string
+		lines  []int
+	}{
+		{
+			name:   "csv options column",
+			format: FormatCSV,
+			input:  "word,meaning,correct,options\nCat,A feline,Cat,Cat| Dog |\nDog,A canine,Dog,Cat|Dog\n",
+			title:  "Animals",
+			lines:  []int{2, 3},
+		},
+		{
+			name:   "csv option columns",
+			format: FormatCSV,
+			input:  "Word,Meaning,Correct,Option1,Option2\nCat,A feline,Cat,Cat,Dog\n",
+			title:  "Animals",
+			lines:  []int{2},
+		},
+		{
+			name:   "json title from file",
+			format: FormatJSON,
+			input:  "{\"title\": \"Pets\", \"extra\": [1],\n \"questions\": [\n  {\"word\": \"Cat\", \"meaning\": \"A feline\", \"correct\": \"Cat\", \"options\": [\"Cat\", \"Dog\"]}]}",
+			title:  "Pets",
+			lines:  []int{3},
+		},
+		{
+			name:   "anki comma separator",
+			format: FormatAnki,
+			input:  "#separator:comma\nCat,A feline\n\nDog,A canine\n",
+			title:  "Animals",
+			lines:  []int{2, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{QuizID: "quiz1"}
+			if tt.format != FormatJSON {
+				opts.Title = "Animals"
+			}
+
+			decoded, err := Decode(strings.NewReader(tt.input), tt.format, opts)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			if decoded.Quiz.ID != "quiz1" || decoded.Quiz.Title != tt.title {
+				t.Errorf("quiz = %q %q, want quiz1 %q", decoded.Quiz.ID, decoded.Quiz.Title, tt.title)
+			}
+
+			var lines []int
+			for _, q := range decoded.Questions {
+				lines = append(lines, decoded.Lines[q.ID])
+				if q.QuizID != "quiz1" {
+					t.Errorf("question %s belongs to %q", q.ID, q.QuizID)
+				}
+				if !slices.Contains(q.Options, q.Correct) {
+					t.Errorf("question %s: correct %q not in options %q", q.ID, q.Correct, q.Options)
+				}
+				if slices.Contains(q.Options, "") {
+					t.Errorf("question %s has an empty option: %q", q.ID, q.Options)
+				}
+			}
+			if !slices.Equal(lines, tt.lines) {
+				t.Errorf("lines = %v, want %v", lines, tt.lines)
+			}
+
+			if err := decoded.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseReportsLintErrorsByLine(t *testing.T) {
+	input := "word,meaning,correct,options\nCat,A feline,Cat,Cat|Dog\nDog,A canine,Wolf,Cat|Dog\n"
+
+	_, err := Parse(strings.NewReader(input), FormatCSV, Options{})
+
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Parse() error = %v, want Errors", err)
+	}
+
+	want := Errors{
+		{Message: "quiz title is required"},
+		{Line: 3, Message: `correct answer "Wolf" is not one of the options`},
+	}
+	if !slices.Equal(errs, want) {
+		t.Errorf("Parse() errors = %v, want %v", errs, want)
+	}
+}
+
+func TestAnkiOptions(t *testing.T) {
+	input := "Cat\tA feline\nDog\tA canine\nBird\tFlies\nFish\tSwims\nCow\tMoos\n"
+
+	first, err := Decode(strings.NewReader(input), FormatAnki, Options{Title: "Animals"})
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	again, err := Decode(strings.NewReader(input), FormatAnki, Options{Title: "Animals"})
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	for i, q := range first.Questions {
+		if len(q.Options) != maxAnkiOptions {
+			t.Errorf("%s: %d options, want %d", q.Word, len(q.Options), maxAnkiOptions)
+		}
+		if q.Correct != q.Word || !slices.Contains(q.Options, q.Word) {
+			t.Errorf("%s: correct %q, options %q", q.Word, q.Correct, q.Options)
+		}
+		if !slices.Equal(q.Options, again.Questions[i].Options) {
+			t.Errorf("%s: options %q, then %q", q.Word, q.Options, again.Questions[i].Options)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package quizlint
+
+import (
+	"fmt"
+	"strings"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // blocks writing and publishing
+	SeverityWarning Severity = "warning" // likely a content mistake
+	SeverityInfo    Severity = "info"    // style suggestion
+)
+
+// Issue is a single rule violation. QuestionID is empty for quiz level issues.
+type Issue struct {
+	Rule       string   `json:"rule"`
+	Severity   Severity `json:"severity"`
+	QuestionID string   `json:"question_id,omitempty"`
+	Message    string   `json:"message"`
+}
+
+// Rule checks one aspect of a quiz
+type Rule struct {
+	Name        string
+	Severity    Severity
+	Description string
+	check       func(quiz *models.Quiz, questions []models.Question, report reportFunc)
+}
+
+type Report struct {
+	Issues []Issue `json:"issues"`
+}
+
+func (r *Report) Count(severity Severity) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *Report) HasErrors() bool {
+	return r.Count(SeverityError) > 0
+}
+
+// Errors returns a report with only the error level issues
+func (r *Report) Errors() *Report {
+	errs := &Report{Issues: []Issue{}}
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			errs.Issues = append(errs.Issues, issue)
+		}
+	}
+	return errs
+}
+
+// ValidationError is returned by writers when a quiz has error level issues
+type ValidationError struct {
+	Report *Report
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Report.Issues))
+	for _, issue := range e.Report.Issues {
+		if issue.QuestionID != "" {
+			msgs = append(msgs, fmt.Sprintf("%s (%s): %s", issue.Rule, issue.QuestionID, issue.Message))
+			continue
+		}
+		msgs = append(msgs, fmt.Sprintf("%s: %s", issue.Rule, issue.Message))
+	}
+	return "quiz is invalid: " + strings.Join(msgs, "; ")
+}
+
+// Lint runs every rule against the quiz
+func Lint(quiz *models.Quiz, questions []models.Question) *Report {
+	report := &Report{Issues: []Issue{}}
+
+	for _, rule := range Rules {
+		rule.check(quiz, questions, func(questionID, format string, args ...interface{}) {
+			report.Issues = append(report.Issues, Issue{
+				Rule:       rule.Name,
+				Severity:   rule.Severity,
+				QuestionID: questionID,
+				Message:    fmt.Sprintf(format, args...),
+			})
+		})
+	}
+
+	return report
+}
+
+// Validate lints the quiz and returns a ValidationError if any error level
+// issue was found
+func Validate(quiz *models.Quiz, questions []models.Question) error {
+	report := Lint(quiz, questions)
+	if report.HasErrors() {
+		return &ValidationError{Report: report.Errors()}
+	}
+	return nil
+}
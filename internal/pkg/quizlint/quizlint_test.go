@@ -0,0 +1,330 @@
+package quizlint
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+// lintQuiz is a quiz every rule passes, cases break one rule at a time
+func lintQuiz() (*models.Quiz, []models.Question) {
+	options := []string{"A mammal", "A bird", "A reptile", "A fish"}
+	return &models.Quiz{ID: "quiz1", Title: "Animals"}, []models.Question{
+		{ID: "q1", QuizID: "quiz1", Word: "Cat", Meaning: "A small feline", Options: slices.Clone(options), Correct: "A mammal"},
+		{ID: "q2", QuizID: "quiz1", Word: "Eagle", Meaning: "A large bird of prey", Options: slices.Clone(options), Correct: "A bird"},
+	}
+}
+
+type issue struct {
+	rule       string
+	questionID string
+	message    string
+}
+
+func TestLintRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(quiz *models.Quiz, questions []models.Question) []models.Question
+		want   []issue
+	}{
+		{
+			name:   "valid quiz",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question { return questions },
+		},
+		{
+			name: "blank title",
+			modify: func(quiz *models.Quiz, questions []models.Question) []models.Question {
+				quiz.Title = "  "
+				return questions
+			},
+			want: []issue{{"quiz-title", "", "quiz title is required"}},
+		},
+		{
+			name:   "no questions",
+			modify: func(*models.Quiz, []models.Question) []models.Question { return nil },
+			want:   []issue{{"has-questions", "", "quiz has no questions"}},
+		},
+		{
+			name: "missing question ID",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].ID = ""
+				return questions
+			},
+			want: []issue{{"question-id", "", "question 2 has no ID"}},
+		},
+		{
+			name: "duplicate question ID",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].ID = "q1"
+				return questions
+			},
+			want: []issue{{"question-id", "q1", "duplicate question ID"}},
+		},
+		{
+			name: "question of another quiz",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].QuizID = "quiz2"
+				return questions
+			},
+			want: []issue{{"question-quiz-id", "q2", `question belongs to quiz "quiz2", not "quiz1"`}},
+		},
+		{
+			name: "unknown question type",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].Type = "essay"
+				return questions
+			},
+			want: []issue{{"question-type", "q2", `unknown question type "essay"`}},
+		},
+		{
+			name: "missing word",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].Word = ""
+				return questions
+			},
+			want: []issue{{"word-required", "q2", "word is required"}},
+		},
+		{
+			name: "missing meaning",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].Meaning = " "
+				return questions
+			},
+			want: []issue{
+				{"meaning-required", "q2", "meaning is required"},
+				{"untrimmed-text", "q2", `" " has surrounding whitespace`},
+			},
+		},
+		{
+			name: "single option",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].Options = []string{"A bird"}
+				return questions
+			},
+			want: []issue{{"min-options", "q2", "at least 2 options are required, got 1"}},
+		},
+		{
+			name: "empty option",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].Options[3] = ""
+				return questions
+			},
+			want: []issue{{"empty-option", "q2", "option 4 is empty"}},
+		},
+		{
+			name: "options differing in case",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].Options[3] = "a bird"
+				return questions
+			},
+			want: []issue{{"duplicate-options", "q2", `duplicate option "a bird"`}},
+		},
+		{
+			name: "correct answer not an option",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[0].Correct = ""
+				questions[1].Correct = "A cat"
+				return questions
+			},
+			want: []issue{
+				{"correct-in-options", "q1", "correct answer is required"},
+				{"correct-in-options", "q2", `correct answer "A cat" is not one of the options`},
+			},
+		},
+		{
+			name: "true/false answered otherwise",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1] = models.Question{ID: "q2", QuizID: "quiz1", Type: models.QuestionTrueFalse, Word: "Eagle", Meaning: "A fish", Correct: "yes"}
+				return questions
+			},
+			want: []issue{{"true-false-answer", "q2", `correct answer must be "true" or "false", got "yes"`}},
+		},
+		{
+			name: "two blanks",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1] = models.Question{ID: "q2", QuizID: "quiz1", Type: models.QuestionFillBlank, Word: "eagle", Sentence: "The ___ flew over the ___."}
+				return questions
+			},
+			want: []issue{{"blank-in-sentence", "q2", "sentence must contain one ___, found 2"}},
+		},
+		{
+			name: "broken pairs",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[0] = models.Question{ID: "q1", QuizID: "quiz1", Type: models.QuestionMatchPairs, Word: "Pets",
+					Pairs: []models.MatchPair{{Word: "Cat", Meaning: "Meows"}}}
+				questions[1] = models.Question{ID: "q2", QuizID: "quiz1", Type: models.QuestionMatchPairs, Word: "Farm",
+					Pairs: []models.MatchPair{{Word: "Cow", Meaning: "Moos"}, {Word: "Pig", Meaning: ""}, {Word: "cow", Meaning: "Grazes"}}}
+				return questions
+			},
+			want: []issue{
+				{"match-pairs", "q1", "at least 2 pairs are required, got 1"},
+				{"match-pairs", "q2", "pair 2 is incomplete"},
+				{"match-pairs", "q2", "pair 3 repeats a word or meaning"},
+			},
+		},
+		{
+			name: "negative time limits",
+			modify: func(quiz *models.Quiz, questions []models.Question) []models.Question {
+				quiz.TimeLimit = -1
+				questions[1].TimeLimit = -5
+				return questions
+			},
+			want: []issue{
+				{"time-limit", "", "quiz time limit -1 is negative"},
+				{"time-limit", "q2", "time limit -5 is negative"},
+			},
+		},
+		{
+			name: "invalid scoring",
+			modify: func(quiz *models.Quiz, questions []models.Question) []models.Question {
+				quiz.Scoring = &models.ScoringConfig{Strategy: "random", WrongPenalty: -1}
+				return questions
+			},
+			want: []issue{
+				{"scoring", "", `unknown scoring strategy "random"`},
+				{"scoring", "", "scoring values cannot be negative"},
+			},
+		},
+		{
+			name: "invalid grading",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[0].Grading = &models.GradingConfig{Metric: "hamming"}
+				questions[1] = models.Question{ID: "q2", QuizID: "quiz1", Type: models.QuestionSpelling, Word: "eagle", Meaning: "A large bird of prey",
+					Grading: &models.GradingConfig{Credit: []float64{1, 0.5, 0.8}}}
+				return questions
+			},
+			want: []issue{
+				{"grading", "q1", "grading only applies to typed questions"},
+				{"grading", "q1", `unknown edit metric "hamming"`},
+				{"grading", "q2", "credit must fall from 1 to 0 as edits grow, got [1 0.5 0.8]"},
+			},
+		},
+		{
+			name: "unknown difficulty",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].Difficulty = "extreme"
+				return questions
+			},
+			want: []issue{{"difficulty", "q2", `unknown difficulty "extreme"`}},
+		},
+		{
+			name: "pool drawing more than there is",
+			modify: func(quiz *models.Quiz, questions []models.Question) []models.Question {
+				quiz.Pool = &models.QuestionPool{Draw: 3, Rules: []models.PoolRule{{Difficulty: "extreme"}, {Min: 2, Max: 1}}}
+				return questions
+			},
+			want: []issue{
+				{"question-pool", "", "pool draws 3 questions from 2"},
+				{"question-pool", "", `pool rule 1 has unknown difficulty "extreme"`},
+				{"question-pool", "", "pool rule 2 has invalid limits 2 to 1"},
+			},
+		},
+		{
+			name: "pool rule needing more than match",
+			modify: func(quiz *models.Quiz, questions []models.Question) []models.Question {
+				quiz.Pool = &models.QuestionPool{Draw: 1, Rules: []models.PoolRule{{Tag: "pets", Min: 1}}}
+				return questions
+			},
+			want: []issue{{"question-pool", "", "pool rule 1 needs 1 questions, 0 match and 1 are drawn"}},
+		},
+		{
+			name: "pool rules ruling each other out",
+			modify: func(quiz *models.Quiz, questions []models.Question) []models.Question {
+				questions[0].Difficulty = models.DifficultyEasy
+				questions[1].Difficulty = models.DifficultyHard
+				quiz.Pool = &models.QuestionPool{Draw: 1, Rules: []models.PoolRule{
+					{Difficulty: models.DifficultyEasy, Min: 1},
+					{Difficulty: models.DifficultyHard, Min: 1},
+				}}
+				return questions
+			},
+			want: []issue{{"question-pool", "", "pool rules cannot be met together, 1 questions to draw"}},
+		},
+		{
+			name: "three options",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].Options = questions[1].Options[:3]
+				return questions
+			},
+			want: []issue{{"option-count", "q2", "has 3 options, 4 are recommended"}},
+		},
+		{
+			name: "word asked twice",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].Word = "CAT"
+				return questions
+			},
+			want: []issue{{"duplicate-word", "q2", `word "CAT" is also asked by question q1`}},
+		},
+		{
+			name: "meaning gives the word away",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].Meaning = "An eagle is a bird"
+				return questions
+			},
+			want: []issue{{"meaning-reveals-word", "q2", `meaning contains the word "Eagle"`}},
+		},
+		{
+			name: "surrounding whitespace",
+			modify: func(_ *models.Quiz, questions []models.Question) []models.Question {
+				questions[1].Meaning = "A large bird of prey "
+				return questions
+			},
+			want: []issue{{"untrimmed-text", "q2", `"A large bird of prey " has surrounding whitespace`}},
+		},
+	}
+
+	severities := make(map[string]Severity, len(Rules))
+	for _, rule := range Rules {
+		severities[rule.Name] = rule.Severity
+	}
+
+	covered := make(map[string]bool, len(Rules))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz, questions := lintQuiz()
+			questions = tt.modify(quiz, questions)
+
+			report := Lint(quiz, questions)
+
+			var got []issue
+			for _, i := range report.Issues {
+				got = append(got, issue{i.Rule, i.QuestionID, i.Message})
+				if i.Severity != severities[i.Rule] {
+					t.Errorf("%s reported as %s, want %s", i.Rule, i.Severity, severities[i.Rule])
+				}
+				covered[i.Rule] = true
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Lint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, rule := range Rules {
+		if !covered[rule.Name] {
+			t.Errorf("rule %s is not covered", rule.Name)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	quiz, questions := lintQuiz()
+	questions[0].Options = questions[0].Options[:3]
+	if err := Validate(quiz, questions); err != nil {
+		t.Errorf("Validate() with a warning = %v, want nil", err)
+	}
+
+	quiz.Title = ""
+	err := Validate(quiz, questions)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Validate() = %v, want a ValidationError", err)
+	}
+	if n := len(validationErr.Report.Issues); n != 1 || validationErr.Report.Issues[0].Rule != "quiz-title" {
+		t.Errorf("ValidationError issues = %v, want only quiz-title", validationErr.Report.Issues)
+	}
+}
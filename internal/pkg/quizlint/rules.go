@@ -0,0 +1,388 @@
+package quizlint
+
+import (
+	"strings"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/pkg/questionpool"
+)
+
+// recommendedOptions is how many options the player UI is designed around
+const recommendedOptions = 4
+
+type reportFunc = func(questionID, format string, args ...interface{})
+
+// Rules are run in this order by Lint
+var Rules = []Rule{
+	{
+		Name:        "quiz-title",
+		Severity:    SeverityError,
+		Description: "the quiz has a title",
+		check: func(quiz *models.Quiz, _ []models.Question, report reportFunc) {
+			if strings.TrimSpace(quiz.Title) == "" {
+				report("", "quiz title is required")
+			}
+		},
+	},
+	{
+		Name:        "has-questions",
+		Severity:    SeverityError,
+		Description: "the quiz has at least one question",
+		check: func(_ *models.Quiz, questions []models.Question, report reportFunc) {
+			if len(questions) == 0 {
+				report("", "quiz has no questions")
+			}
+		},
+	},
+	{
+		Name:        "question-id",
+		Severity:    SeverityError,
+		Description: "every question has a unique ID",
+		check: func(_ *models.Quiz, questions []models.Question, report reportFunc) {
+			seen := make(map[string]bool, len(questions))
+			for i, q := range questions {
+				if q.ID == "" {
+					report("", "question %d has no ID", i+1)
+					continue
+				}
+				if seen[q.ID] {
+					report(q.ID, "duplicate question ID")
+				}
+				seen[q.ID] = true
+			}
+		},
+	},
+	{
+		Name:        "question-quiz-id",
+		Severity:    SeverityError,
+		Description: "every question belongs to the quiz",
+		check: eachQuestion(func(quiz *models.Quiz, q models.Question, report reportFunc) {
+			if q.QuizID != quiz.ID {
+				report(q.ID, "question belongs to quiz %q, not %q", q.QuizID, quiz.ID)
+			}
+		}),
+	},
+	{
+		Name:        "question-type",
+		Severity:    SeverityError,
+		Description: "every question has a known type",
+		check: eachQuestion(func(_ *models.Quiz, q models.Question, report reportFunc) {
+			if !q.Kind().IsValid() {
+				report(q.ID, "unknown question type %q", q.Type)
+			}
+		}),
+	},
+	{
+		Name:        "word-required",
+		Severity:    SeverityError,
+		Description: "every question has a word",
+		check: eachQuestion(func(_ *models.Quiz, q models.Question, report reportFunc) {
+			if strings.TrimSpace(q.Word) == "" {
+				report(q.ID, "word is required")
+			}
+		}),
+	},
+	{
+		Name:        "meaning-required",
+		Severity:    SeverityError,
+		Description: "questions that show a meaning have one",
+		check: eachQuestion(func(_ *models.Quiz, q models.Question, report reportFunc) {
+			if !showsMeaning(q.Kind()) {
+				return
+			}
+			if strings.TrimSpace(q.Meaning) == "" {
+				report(q.ID, "meaning is required")
+			}
+		}),
+	},
+	{
+		Name:        "min-options",
+		Severity:    SeverityError,
+		Description: "questions with options have at least two",
+		check: eachOptionQuestion(func(q models.Question, report reportFunc) {
+			if len(q.Options) < 2 {
+				report(q.ID, "at least 2 options are required, got %d", len(q.Options))
+			}
+		}),
+	},
+	{
+		Name:        "empty-option",
+		Severity:    SeverityError,
+		Description: "no option is blank",
+		check: eachOptionQuestion(func(q models.Question, report reportFunc) {
+			for i, option := range q.Options {
+				if strings.TrimSpace(option) == "" {
+					report(q.ID, "option %d is empty", i+1)
+				}
+			}
+		}),
+	},
+	{
+		Name:        "duplicate-options",
+		Severity:    SeverityError,
+		Description: "options are unique within a question",
+		check: eachOptionQuestion(func(q models.Question, report reportFunc) {
+			seen := make(map[string]bool, len(q.Options))
+			for _, option := range q.Options {
+				key := strings.ToLower(strings.TrimSpace(option))
+				if seen[key] {
+					report(q.ID, "duplicate option %q", option)
+				}
+				seen[key] = true
+			}
+		}),
+	},
+	{
+		Name:        "correct-in-options",
+		Severity:    SeverityError,
+		Description: "the correct answer is one of the options",
+		check: eachOptionQuestion(func(q models.Question, report reportFunc) {
+			if q.Correct == "" {
+				report(q.ID, "correct answer is required")
+				return
+			}
+			for _, option := range q.Options {
+				if option == q.Correct {
+					return
+				}
+			}
+			report(q.ID, "correct answer %q is not one of the options", q.Correct)
+		}),
+	},
+	{
+		Name:        "true-false-answer",
+		Severity:    SeverityError,
+		Description: "true/false questions are answered with true or false",
+		check: eachQuestion(func(_ *models.Quiz, q models.Question, report reportFunc) {
+			if q.Kind() == models.QuestionTrueFalse && q.Correct != "true" && q.Correct != "false" {
+				report(q.ID, "correct answer must be \"true\" or \"false\", got %q", q.Correct)
+			}
+		}),
+	},
+	{
+		Name:        "blank-in-sentence",
+		Severity:    SeverityError,
+		Description: "fill-in-the-blank sentences have exactly one blank",
+		check: eachQuestion(func(_ *models.Quiz, q models.Question, report reportFunc) {
+			if q.Kind() != models.QuestionFillBlank {
+				return
+			}
+			if n := strings.Count(q.Sentence, models.BlankMarker); n != 1 {
+				report(q.ID, "sentence must contain one %s, found %d", models.BlankMarker, n)
+			}
+		}),
+	},
+	{
+		Name:        "match-pairs",
+		Severity:    SeverityError,
+		Description: "matching questions have at least two complete pairs with unique sides",
+		check: eachQuestion(func(_ *models.Quiz, q models.Question, report reportFunc) {
+			if q.Kind() != models.QuestionMatchPairs {
+				return
+			}
+			if len(q.Pairs) < 2 {
+				report(q.ID, "at least 2 pairs are required, got %d", len(q.Pairs))
+			}
+			words := make(map[string]bool, len(q.Pairs))
+			meanings := make(map[string]bool, len(q.Pairs))
+			for i, pair := range q.Pairs {
+				word := strings.ToLower(strings.TrimSpace(pair.Word))
+				meaning := strings.ToLower(strings.TrimSpace(pair.Meaning))
+				if word == "" || meaning == "" {
+					report(q.ID, "pair %d is incomplete", i+1)
+					continue
+				}
+				if words[word] || meanings[meaning] {
+					report(q.ID, "pair %d repeats a word or meaning", i+1)
+				}
+				words[word] = true
+				meanings[meaning] = true
+			}
+		}),
+	},
+	{
+		Name:        "time-limit",
+		Severity:    SeverityError,
+		Description: "time limits are not negative",
+		check: func(quiz *models.Quiz, questions []models.Question, report reportFunc) {
+			if quiz.TimeLimit < 0 {
+				report("", "quiz time limit %d is negative", quiz.TimeLimit)
+			}
+			for _, q := range questions {
+				if q.TimeLimit < 0 {
+					report(q.ID, "time limit %d is negative", q.TimeLimit)
+				}
+			}
+		},
+	},
+	{
+		Name:        "scoring",
+		Severity:    SeverityError,
+		Description: "the scoring config uses a known strategy and no negative values",
+		check: func(quiz *models.Quiz, _ []models.Question, report reportFunc) {
+			cfg := quiz.Scoring
+			if cfg == nil {
+				return
+			}
+			if cfg.Strategy != "" && !cfg.Strategy.IsValid() {
+				report("", "unknown scoring strategy %q", cfg.Strategy)
+			}
+			if cfg.StreakBonus < 0 || cfg.MaxStreak < 0 || cfg.FirstCorrectBonus < 0 || cfg.WrongPenalty < 0 {
+				report("", "scoring values cannot be negative")
+			}
+		},
+	},
+	{
+		Name:        "grading",
+		Severity:    SeverityError,
+		Description: "typed answer grading uses a known metric and credit falling from 1 to 0",
+		check: eachQuestion(func(_ *models.Quiz, q models.Question, report reportFunc) {
+			cfg := q.Grading
+			if cfg == nil {
+				return
+			}
+			if !q.Kind().Typed() {
+				report(q.ID, "grading only applies to typed questions")
+			}
+			if cfg.Metric != "" && !cfg.Metric.IsValid() {
+				report(q.ID, "unknown edit metric %q", cfg.Metric)
+			}
+			for i, credit := range cfg.Credit {
+				if credit < 0 || credit > 1 || (i > 0 && credit > cfg.Credit[i-1]) {
+					report(q.ID, "credit must fall from 1 to 0 as edits grow, got %v", cfg.Credit)
+					break
+				}
+			}
+		}),
+	},
+	{
+		Name:        "difficulty",
+		Severity:    SeverityError,
+		Description: "question difficulties are easy, medium or hard",
+		check: eachQuestion(func(_ *models.Quiz, q models.Question, report reportFunc) {
+			if q.Difficulty != "" && !q.Difficulty.IsValid() {
+				report(q.ID, "unknown difficulty %q", q.Difficulty)
+			}
+		}),
+	},
+	{
+		Name:        "question-pool",
+		Severity:    SeverityError,
+		Description: "a question pool draws from enough questions to meet its rules",
+		check: func(quiz *models.Quiz, questions []models.Question, report reportFunc) {
+			pool := quiz.Pool
+			if pool == nil {
+				return
+			}
+			valid := true
+			if pool.Draw < 1 || pool.Draw > len(questions) {
+				report("", "pool draws %d questions from %d", pool.Draw, len(questions))
+				valid = false
+			}
+			for i, rule := range pool.Rules {
+				if rule.Difficulty != "" && !rule.Difficulty.IsValid() {
+					report("", "pool rule %d has unknown difficulty %q", i+1, rule.Difficulty)
+					valid = false
+				}
+				if rule.Min < 0 || rule.Max < 0 || (rule.Max > 0 && rule.Min > rule.Max) {
+					report("", "pool rule %d has invalid limits %d to %d", i+1, rule.Min, rule.Max)
+					valid = false
+				}
+
+				matching := 0
+				for _, q := range questions {
+					if rule.Matches(q) {
+						matching++
+					}
+				}
+				if rule.Min > matching || rule.Min > pool.Draw {
+					report("", "pool rule %d needs %d questions, %d match and %d are drawn", i+1, rule.Min, matching, pool.Draw)
+					valid = false
+				}
+			}
+
+			// Rules that can each be met may still rule each other out
+			if valid {
+				if err := questionpool.Check(questions, pool); err != nil {
+					report("", "pool rules cannot be met together, %d questions to draw", pool.Draw)
+				}
+			}
+		},
+	},
+	{
+		Name:        "option-count",
+		Severity:    SeverityWarning,
+		Description: "questions have the recommended number of options",
+		check: eachOptionQuestion(func(q models.Question, report reportFunc) {
+			if len(q.Options) >= 2 && len(q.Options) != recommendedOptions {
+				report(q.ID, "has %d options, %d are recommended", len(q.Options), recommendedOptions)
+			}
+		}),
+	},
+	{
+		Name:        "duplicate-word",
+		Severity:    SeverityWarning,
+		Description: "a word is asked only once per quiz",
+		check: func(_ *models.Quiz, questions []models.Question, report reportFunc) {
+			seen := make(map[string]string, len(questions))
+			for _, q := range questions {
+				key := strings.ToLower(strings.TrimSpace(q.Word))
+				if key == "" {
+					continue
+				}
+				if first, ok := seen[key]; ok {
+					report(q.ID, "word %q is also asked by question %s", q.Word, first)
+					continue
+				}
+				seen[key] = q.ID
+			}
+		},
+	},
+	{
+		Name:        "meaning-reveals-word",
+		Severity:    SeverityWarning,
+		Description: "the meaning does not contain the word itself",
+		check: eachQuestion(func(_ *models.Quiz, q models.Question, report reportFunc) {
+			word := strings.ToLower(strings.TrimSpace(q.Word))
+			if word != "" && strings.Contains(strings.ToLower(q.Meaning), word) {
+				report(q.ID, "meaning contains the word %q", q.Word)
+			}
+		}),
+	},
+	{
+		Name:        "untrimmed-text",
+		Severity:    SeverityInfo,
+		Description: "text has no leading or trailing whitespace",
+		check: eachQuestion(func(_ *models.Quiz, q models.Question, report reportFunc) {
+			fields := append([]string{q.Word, q.Meaning, q.Correct, q.Sentence}, q.Options...)
+			for _, field := range fields {
+				if field != strings.TrimSpace(field) {
+					report(q.ID, "%q has surrounding whitespace", field)
+				}
+			}
+		}),
+	},
+}
+
+func eachQuestion(check func(quiz *models.Quiz, q models.Question, report reportFunc)) func(*models.Quiz, []models.Question, reportFunc) {
+	return func(quiz *models.Quiz, questions []models.Question, report reportFunc) {
+		for _, q := range questions {
+			check(quiz, q, report)
+		}
+	}
+}
+
+// eachOptionQuestion runs check on the questions players answer by picking
+// one of the options
+func eachOptionQuestion(check func(q models.Question, report reportFunc)) func(*models.Quiz, []models.Question, reportFunc) {
+	return eachQuestion(func(_ *models.Quiz, q models.Question, report reportFunc) {
+		if q.Kind().HasOptions() {
+			check(q, report)
+		}
+	})
+}
+
+// showsMeaning reports whether players are shown the question's meaning
+func showsMeaning(t models.QuestionType) bool {
+	return t == models.QuestionMultipleChoice || t == models.QuestionTrueFalse || t == models.QuestionSpelling
+}
@@ -0,0 +1,117 @@
+package rating
+
+import (
+	"math"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+const (
+	// DefaultRating is where players and questions without a difficulty
+	// start, an average player answers an average question half of the time
+	DefaultRating = 1500
+
+	// MinAnswers is how many answers a question needs before the difficulty
+	// computed from its rating is used
+	MinAnswers = 10
+
+	// scale is the rating difference at which the stronger side is expected
+	// to win ten times as often
+	scale = 400
+
+	// Ratings move by up to k points per answer. New players and questions
+	// move faster until provisionalAnswers, questions settle slower than
+	// players since every player's answer moves them.
+	playerK            = 32
+	questionK          = 16
+	provisionalK       = 48
+	provisionalAnswers = 10
+
+	// Questions rated below easyBelow are easy, above hardAbove hard
+	easyBelow = 1400
+	hardAbove = 1600
+)
+
+// Initial is the starting rating of a question of the given authored
+// difficulty
+func Initial(d models.Difficulty) float64 {
+	switch d {
+	case models.DifficultyEasy:
+		return DefaultRating - 200
+	case models.DifficultyHard:
+		return DefaultRating + 200
+	}
+	return DefaultRating
+}
+
+// Expected is the chance a player rated player answers a question rated
+// question correctly
+func Expected(player, question float64) float64 {
+	return 1 / (1 + math.Pow(10, (question-player)/scale))
+}
+
+// Outcome scores an answer from 0 for wrong or missed to 1 for right,
+// typed answers with typos in between by their credit
+func Outcome(answer models.Answer) float64 {
+	if !answer.Correct {
+		return 0
+	}
+	if answer.Grading != nil {
+		return answer.Grading.Credit
+	}
+	return 1
+}
+
+// Update moves the ratings of a player and the question they answered with
+// the given outcome
+func Update(player, question *models.Rating, outcome float64, now time.Time) {
+	surprise := outcome - Expected(player.Value, question.Value)
+
+	player.Value += k(player.Answers, playerK) * surprise
+	question.Value -= k(question.Answers, questionK) * surprise
+
+	player.Answers++
+	question.Answers++
+	player.UpdatedAt = now
+	question.UpdatedAt = now
+}
+
+func k(answers int, settled float64) float64 {
+	if answers < provisionalAnswers {
+		return provisionalK
+	}
+	return settled
+}
+
+// Difficulty is the difficulty a question's rating puts it at, empty until
+// it has MinAnswers answers
+func Difficulty(r models.Rating) models.Difficulty {
+	switch {
+	case r.Answers < MinAnswers:
+		return ""
+	case r.Value < easyBelow:
+		return models.DifficultyEasy
+	case r.Value > hardAbove:
+		return models.DifficultyHard
+	}
+	return models.DifficultyMedium
+}
+
+// Weight scales the points of a question rated value: 1 at DefaultRating,
+// doubling every scale points above it and halving below, within [0.5, 2]
+func Weight(value float64) float64 {
+	return math.Max(0.5, math.Min(2, math.Pow(2, (value-DefaultRating)/scale)))
+}
+
+// Nearest returns the index of the value closest to target, the first one
+// on ties, or -1 when values is empty
+func Nearest(target float64, values []float64) int {
+	best := -1
+	for i, v := range values {
+		if best < 0 || math.Abs(v-target) < math.Abs(values[best]-target) {
+			best = i
+		}
+	}
+	return best
+}
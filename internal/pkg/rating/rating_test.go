@@ -0,0 +1,178 @@
+package rating
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestExpected(t *testing.T) {
+	tests := []struct {
+		player, question float64
+		want             float64
+	}{
+		{1500, 1500, 0.5},
+		{1900, 1500, 10.0 / 11},
+		{1500, 1900, 1.0 / 11},
+		{1700, 1500, 1 / (1 + math.Pow(10, -0.5))},
+	}
+
+	for _, tt := range tests {
+		if got := Expected(tt.player, tt.question); !near(got, tt.want) {
+			t.Errorf("Expected(%v, %v) = %v, want %v", tt.player, tt.question, got, tt.want)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		player, question models.Rating
+		outcome          float64
+		wantPlayer       float64
+		wantQuestion     float64
+	}{
+		{
+			name:         "new player right on an even question",
+			player:       models.Rating{Value: 1500},
+			question:     models.Rating{Value: 1500},
+			outcome:      1,
+			wantPlayer:   1500 + provisionalK*0.5,
+			wantQuestion: 1500 - provisionalK*0.5,
+		},
+		{
+			name:         "settled player wrong on an even question",
+			player:       models.Rating{Value: 1500, Answers: provisionalAnswers},
+			question:     models.Rating{Value: 1500, Answers: provisionalAnswers},
+			outcome:      0,
+			wantPlayer:   1500 - playerK*0.5,
+			wantQuestion: 1500 + questionK*0.5,
+		},
+		{
+			name:         "partial credit as expected moves nothing",
+			player:       models.Rating{Value: 1500, Answers: 20},
+			question:     models.Rating{Value: 1500, Answers: 20},
+			outcome:      0.5,
+			wantPlayer:   1500,
+			wantQuestion: 1500,
+		},
+		{
+			name:         "a strong player gains little on an easy question",
+			player:       models.Rating{Value: 1900, Answers: 20},
+			question:     models.Rating{Value: 1500, Answers: 20},
+			outcome:      1,
+			wantPlayer:   1900 + playerK*(1.0/11),
+			wantQuestion: 1500 - questionK*(1.0/11),
+		},
+		{
+			name:         "a weak player loses little on a hard question",
+			player:       models.Rating{Value: 1500, Answers: 20},
+			question:     models.Rating{Value: 1900, Answers: 3},
+			outcome:      0,
+			wantPlayer:   1500 - playerK*(1.0/11),
+			wantQuestion: 1900 + provisionalK*(1.0/11),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player, question := tt.player, tt.question
+			Update(&player, &question, tt.outcome, now)
+
+			if !near(player.Value, tt.wantPlayer) || !near(question.Value, tt.wantQuestion) {
+				t.Errorf("Update() = player %v question %v, want %v and %v",
+					player.Value, question.Value, tt.wantPlayer, tt.wantQuestion)
+			}
+			if player.Answers != tt.player.Answers+1 || question.Answers != tt.question.Answers+1 {
+				t.Errorf("Update() counted %d and %d answers", player.Answers, question.Answers)
+			}
+			if !player.UpdatedAt.Equal(now) || !question.UpdatedAt.Equal(now) {
+				t.Errorf("Update() did not stamp both ratings")
+			}
+		})
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer models.Answer
+		want   float64
+	}{
+		{"wrong", models.Answer{}, 0},
+		{"right", models.Answer{Correct: true}, 1},
+		{"typos", models.Answer{Correct: true, Grading: &models.Grading{Credit: 0.5}}, 0.5},
+	}
+
+	for _, tt := range tests {
+		if got := Outcome(tt.answer); got != tt.want {
+			t.Errorf("%s: Outcome() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDifficulty(t *testing.T) {
+	tests := []struct {
+		rating models.Rating
+		want   models.Difficulty
+	}{
+		{models.Rating{Value: 1000, Answers: MinAnswers - 1}, ""},
+		{models.Rating{Value: 1399, Answers: MinAnswers}, models.DifficultyEasy},
+		{models.Rating{Value: 1400, Answers: MinAnswers}, models.DifficultyMedium},
+		{models.Rating{Value: 1600, Answers: MinAnswers}, models.DifficultyMedium},
+		{models.Rating{Value: 1601, Answers: MinAnswers}, models.DifficultyHard},
+	}
+
+	for _, tt := range tests {
+		if got := Difficulty(tt.rating); got != tt.want {
+			t.Errorf("Difficulty(%+v) = %q, want %q", tt.rating, got, tt.want)
+		}
+	}
+}
+
+func TestWeight(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  float64
+	}{
+		{DefaultRating, 1},
+		{DefaultRating + scale, 2},
+		{DefaultRating - scale, 0.5},
+		{DefaultRating + scale/2, math.Sqrt2},
+		{DefaultRating + 3*scale, 2},
+		{DefaultRating - 3*scale, 0.5},
+	}
+
+	for _, tt := range tests {
+		if got := Weight(tt.value); !near(got, tt.want) {
+			t.Errorf("Weight(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	tests := []struct {
+		target float64
+		values []float64
+		want   int
+	}{
+		{1500, nil, -1},
+		{1500, []float64{1300, 1450, 1700}, 1},
+		{1500, []float64{1400, 1600}, 0},
+		{2000, []float64{1300, 1450, 1700}, 2},
+	}
+
+	for _, tt := range tests {
+		if got := Nearest(tt.target, tt.values); got != tt.want {
+			t.Errorf("Nearest(%v, %v) = %d, want %d", tt.target, tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package review
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+// The scheduler follows SM-2. Every answer is rated from 0 to MaxQuality, a
+// word answered at PassingQuality or better is reviewed again after 1 day,
+// then 6 days, then each interval times the ease. A failed word starts over
+// the next day. The ease drops for words that are hard to recall.
+const (
+	DefaultEase    = 2.5
+	MinEase        = 1.3
+	PassingQuality = 3
+	MaxQuality     = 5
+
+	// fastShare of the time limit is quick enough for a perfect rating
+	fastShare = 1.0 / 3
+)
+
+// Quality rates an answer: 0 when nothing was answered in time, 1 when it
+// was wrong, 3 when it was right with typos, and 4 when it was right, or 5
+// when the server timed it at most a third of the time limit.
+func Quality(answer models.Answer, timeLimit float64) int {
+	switch {
+	case answer.TimedOut || answer.PlayerChoice == "":
+		return 0
+	case !answer.Correct:
+		return 1
+	case answer.Grading != nil && answer.Grading.Credit < 1:
+		return PassingQuality
+	case answer.Timed && timeLimit > 0 && answer.AnswerTime <= timeLimit*fastShare:
+		return MaxQuality
+	}
+	return 4
+}
+
+// Schedule moves item to its next review after an answer of the given
+// quality at now. A new item starts at DefaultEase.
+func Schedule(item *models.ReviewItem, quality int, now time.Time) {
+	quality = max(0, min(MaxQuality, quality))
+	if item.Ease == 0 {
+		item.Ease = DefaultEase
+	}
+
+	if quality < PassingQuality {
+		if item.Repetitions > 0 {
+			item.Lapses++
+		}
+		item.Repetitions = 0
+		item.Interval = 1
+	} else {
+		item.Repetitions++
+		switch item.Repetitions {
+		case 1:
+			item.Interval = 1
+		case 2:
+			item.Interval = 6
+		default:
+			item.Interval = int(math.Round(float64(item.Interval) * item.Ease))
+		}
+	}
+
+	miss := float64(MaxQuality - quality)
+	item.Ease = math.Max(MinEase, item.Ease+0.1-miss*(0.08+miss*0.02))
+
+	item.Quality = quality
+	item.ReviewedAt = now
+	item.DueAt = now.AddDate(0, 0, item.Interval)
+}
+
+// Due returns the items due at now, the longest overdue first and the
+// hardest first among those due at the same time
+func Due(items []*models.ReviewItem, now time.Time) []*models.ReviewItem {
+	due := make([]*models.ReviewItem, 0, len(items))
+	for _, item := range items {
+		if !item.DueAt.After(now) {
+			due = append(due, item)
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		if !due[i].DueAt.Equal(due[j].DueAt) {
+			return due[i].DueAt.Before(due[j].DueAt)
+		}
+		return due[i].Ease < due[j].Ease
+	})
+
+	return due
+}
+
+// NextDue returns when the first item not yet due at now comes due, nil when
+// every item is due
+func NextDue(items []*models.ReviewItem, now time.Time) *time.Time {
+	var next *time.Time
+	for _, item := range items {
+		if item.DueAt.After(now) && (next == nil || item.DueAt.Before(*next)) {
+			due := item.DueAt
+			next = &due
+		}
+	}
+	return next
+}
@@ -0,0 +1,114 @@
+package review
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+func TestQuality(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer models.Answer
+		limit  float64
+		want   int
+	}{
+		{"no answer", models.Answer{}, 30, 0},
+		{"timed out", models.Answer{PlayerChoice: "a", Correct: true, TimedOut: true, Timed: true}, 30, 0},
+		{"wrong", models.Answer{PlayerChoice: "a"}, 30, 1},
+		{"typos", models.Answer{PlayerChoice: "a", Correct: true, Grading: &models.Grading{Credit: 0.5}}, 30, PassingQuality},
+		{"right", models.Answer{PlayerChoice: "a", Correct: true, AnswerTime: 20, Timed: true}, 30, 4},
+		{"right and fast", models.Answer{PlayerChoice: "a", Correct: true, AnswerTime: 10, Timed: true}, 30, MaxQuality},
+		{"fast but not timed by the server", models.Answer{PlayerChoice: "a", Correct: true, AnswerTime: 1}, 30, 4},
+		{"fast without a time limit", models.Answer{PlayerChoice: "a", Correct: true, AnswerTime: 1, Timed: true}, 0, 4},
+		{"exact after grading", models.Answer{PlayerChoice: "a", Correct: true, AnswerTime: 1, Timed: true, Grading: &models.Grading{Credit: 1}}, 30, MaxQuality},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Quality(tt.answer, tt.limit); got != tt.want {
+				t.Errorf("Quality() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		qualities []int
+		interval  int // days after the last review
+		ease      float64
+		lapses    int
+	}{
+		{"first pass", []int{4}, 1, 2.5, 0},
+		{"second pass", []int{4, 4}, 6, 2.5, 0},
+		{"third pass multiplies by the ease", []int{4, 4, 4}, 15, 2.5, 0},
+		{"perfect answers raise the ease", []int{5, 5, 5}, 16, 2.8, 0},
+		{"passing answers lower the ease", []int{3, 3, 3}, 13, 2.08, 0},
+		{"a fail starts over", []int{4, 4, 1}, 1, 1.96, 1},
+		{"a first fail is no lapse", []int{0}, 1, 1.7, 0},
+		{"the ease stops at the minimum", []int{0, 0, 0, 0, 0, 0}, 1, MinEase, 0},
+		{"quality is clamped", []int{9, 9}, 6, 2.7, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &models.ReviewItem{}
+			at := now
+			for _, quality := range tt.qualities {
+				Schedule(item, quality, at)
+				at = item.DueAt
+			}
+
+			last := item.ReviewedAt
+			if item.Interval != tt.interval || !item.DueAt.Equal(last.AddDate(0, 0, tt.interval)) {
+				t.Errorf("interval %d due %v, want %d days after %v", item.Interval, item.DueAt, tt.interval, last)
+			}
+			if math.Abs(item.Ease-tt.ease) > 1e-9 {
+				t.Errorf("ease = %v, want %v", item.Ease, tt.ease)
+			}
+			if item.Lapses != tt.lapses {
+				t.Errorf("lapses = %d, want %d", item.Lapses, tt.lapses)
+			}
+		})
+	}
+}
+
+func TestDue(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	items := []*models.ReviewItem{
+		{WordID: "later", DueAt: now.Add(2 * day), Ease: 2.5},
+		{WordID: "easy", DueAt: now.Add(-day), Ease: 2.5},
+		{WordID: "now", DueAt: now, Ease: 2.5},
+		{WordID: "hard", DueAt: now.Add(-day), Ease: 1.3},
+		{WordID: "soon", DueAt: now.Add(day), Ease: 2.5},
+		{WordID: "oldest", DueAt: now.Add(-3 * day), Ease: 2.5},
+	}
+
+	due := Due(items, now)
+	want := []string{"oldest", "hard", "easy", "now"}
+	if len(due) != len(want) {
+		t.Fatalf("Due() returned %d items, want %d", len(due), len(want))
+	}
+	for i, item := range due {
+		if item.WordID != want[i] {
+			t.Errorf("Due()[%d] = %s, want %s", i, item.WordID, want[i])
+		}
+	}
+
+	next := NextDue(items, now)
+	if next == nil || !next.Equal(now.Add(day)) {
+		t.Errorf("NextDue() = %v, want %v", next, now.Add(day))
+	}
+
+	if next := NextDue(items, now.Add(3*day)); next != nil {
+		t.Errorf("NextDue() with everything due = %v, want nil", next)
+	}
+}
@@ -28,6 +28,10 @@ func (c *Client) writePump() {
 			return
 		}
 	}
+
+	// Send is only closed when the player was removed from the room, the
+	// messages queued before that have been written
+	c.Conn.Close()
 }
 
 func (c *Client) readPump() {
@@ -54,7 +58,10 @@ func (c *Client) readPump() {
 				return
 			}
 		case websocket.OpText:
-			// Handle text messages if needed
+			if handler := c.Hub.messageHandler(); handler != nil {
+				handler(c.SessionID, c.PlayerID, frame.Payload)
+				continue
+			}
 			log.Println("Received message:", string(frame.Payload))
 		}
 	}
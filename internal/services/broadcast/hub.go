@@ -2,9 +2,12 @@ package broadcast
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"sync"
 
 	"wordwizardry/internal/pkg/models"
@@ -14,11 +17,34 @@ import (
 type Hub interface {
 	Run()
 	CreateRoom(sessionID string) error
+	DeleteRoom(sessionID string) error
 	JoinRoom(sessionID string, playerID string) error
 	LeaveRoom(sessionID string, playerID string) error
 	BroadcastToRoom(ctx context.Context, sessionID string, message models.WSMessage) error
+	NotifyRoom(ctx context.Context, sessionID string, message models.WSMessage) error
 	SendToPlayer(ctx context.Context, sessionID, playerID string, message models.WSMessage) error
-	HandleWebSocket(w http.ResponseWriter, r *http.Request) error
+	HandleWebSocket(w http.ResponseWriter, r *http.Request, playerID string) (*Resume, error)
+	SetMessageHandler(handler MessageHandler)
+}
+
+// MessageHandler is called with every text message a client sends. Calls for
+// one connection are sequential, different connections call concurrently.
+type MessageHandler func(sessionID, playerID string, payload []byte)
+
+// ErrInvalidLastSeq is returned by HandleWebSocket for a last_seq that is
+// not a sequence number, before the connection is upgraded
+var ErrInvalidLastSeq = errors.New("invalid last_seq")
+
+// Resume describes how a connection caught up with its room. A client that
+// reconnects with last_seq gets every buffered message it missed, unless the
+// buffer no longer reaches back that far, in which case Gap is set and the
+// caller is expected to send a full state snapshot.
+type Resume struct {
+	Resumed  bool   // the client asked to resume from LastSeq
+	LastSeq  uint64 // last sequence number the client had seen
+	Seq      uint64 // room sequence number at connect time
+	Replayed int    // number of buffered messages re-sent
+	Gap      bool   // messages after LastSeq are no longer buffered
 }
 
 type WebSocketHub struct {
@@ -26,6 +52,8 @@ type WebSocketHub struct {
 	register   chan *Client
 	unregister chan *Client
 	mu         sync.RWMutex
+
+	onMessage MessageHandler
 }
 
 func NewWebSocketHub() *WebSocketHub {
@@ -50,6 +78,13 @@ func (h *WebSocketHub) Run() {
 			}
 
 			room.mu.Lock()
+			// Removed from the room while the connection was being set up
+			if !room.players[client.PlayerID] {
+				room.mu.Unlock()
+				client.Conn.Close()
+				continue
+			}
+
 			_, ok := room.clients[client.PlayerID]
 			if ok {
 				// Mark for cleanup without closing channel
@@ -72,12 +107,8 @@ func (h *WebSocketHub) Run() {
 						delete(room.clients, client.PlayerID)
 					}
 				}
-				// Only remove room if truly empty
-				if len(room.clients) == 0 {
-					h.mu.Lock()
-					delete(h.rooms, client.SessionID)
-					h.mu.Unlock()
-				}
+				// The room outlives its connections so that players can
+				// reconnect and resume from their last sequence number.
 				room.mu.Unlock()
 			}
 			// Close channel after all locks are released
@@ -89,17 +120,43 @@ func (h *WebSocketHub) Run() {
 	}
 }
 
-func (h *WebSocketHub) HandleWebSocket(w http.ResponseWriter, r *http.Request) error {
+// SetMessageHandler routes client messages to handler, replacing any
+// previous one
+func (h *WebSocketHub) SetMessageHandler(handler MessageHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onMessage = handler
+}
+
+func (h *WebSocketHub) messageHandler() MessageHandler {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.onMessage
+}
+
+// HandleWebSocket upgrades the request and attaches the connection to the
+// room of the session_id query parameter as playerID, which must have joined
+// the room before.
+func (h *WebSocketHub) HandleWebSocket(w http.ResponseWriter, r *http.Request, playerID string) (*Resume, error) {
 	sessionID := r.URL.Query().Get("session_id")
-	playerID := r.URL.Query().Get("player_id")
+
+	resume := &Resume{}
+	if lastSeq := r.URL.Query().Get("last_seq"); lastSeq != "" {
+		seq, err := strconv.ParseUint(lastSeq, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidLastSeq, err)
+		}
+		resume.Resumed = true
+		resume.LastSeq = seq
+	}
 
 	if !h.IsPlayerRegistered(sessionID, playerID) {
-		return fmt.Errorf("player not registered in room")
+		return nil, fmt.Errorf("player not registered in room")
 	}
 
 	conn, err := websocket.Upgrade(w, r)
 	if err != nil {
-		return fmt.Errorf("websocket upgrade failed: %w", err)
+		return nil, fmt.Errorf("websocket upgrade failed: %w", err)
 	}
 
 	client := &Client{
@@ -111,11 +168,37 @@ func (h *WebSocketHub) HandleWebSocket(w http.ResponseWriter, r *http.Request) e
 	}
 
 	h.mu.RLock()
-	room := h.rooms[sessionID]
+	room, exists := h.rooms[sessionID]
 	h.mu.RUnlock()
 
+	if !exists {
+		conn.Close()
+		return nil, fmt.Errorf("room not found")
+	}
+
+	// Register and replay under the room lock so that no broadcast can slip
+	// in between the missed messages and the live stream.
 	room.mu.Lock()
+	if room.deleted {
+		room.mu.Unlock()
+		conn.Close()
+		return nil, fmt.Errorf("room not found")
+	}
 	room.clients[playerID] = client
+	resume.Seq = room.seq
+	if resume.Resumed {
+		missed, ok := room.eventsSince(resume.LastSeq)
+		resume.Gap = !ok
+		for _, message := range missed {
+			data, err := json.Marshal(message)
+			if err != nil {
+				log.Printf("failed to marshal replayed message: %v", err)
+				continue
+			}
+			client.Send <- data
+			resume.Replayed++
+		}
+	}
 	room.mu.Unlock()
 
 	h.register <- client
@@ -123,5 +206,5 @@ func (h *WebSocketHub) HandleWebSocket(w http.ResponseWriter, r *http.Request) e
 	go client.writePump()
 	go client.readPump()
 
-	return nil
+	return resume, nil
 }
@@ -9,10 +9,51 @@ import (
 	"wordwizardry/internal/pkg/models"
 )
 
+// maxRoomEvents bounds how many broadcast messages a room keeps for replay.
+// Clients that fall further behind receive a full state snapshot instead.
+const maxRoomEvents = 128
+
 type Room struct {
 	clients map[string]*Client // playerID -> client
 	mu      sync.RWMutex
 	players map[string]bool // playerID -> true
+
+	seq    uint64             // sequence number of the last broadcast message
+	events []models.WSMessage // most recent broadcast messages, oldest first
+
+	deleted bool // removed from the hub, takes no more connections
+}
+
+// record keeps a sequenced message in the replay buffer. Callers must hold
+// room.mu for writing.
+func (room *Room) record(message models.WSMessage) {
+	room.seq = message.Seq
+
+	room.events = append(room.events, message)
+	if len(room.events) > maxRoomEvents {
+		room.events = room.events[len(room.events)-maxRoomEvents:]
+	}
+}
+
+// eventsSince returns the buffered messages after lastSeq. The second return
+// value is false when some of those messages have already been evicted, or
+// when lastSeq is ahead of the room because the client saw an earlier room of
+// the session, e.g. before a restart. Callers must hold room.mu.
+func (room *Room) eventsSince(lastSeq uint64) ([]models.WSMessage, bool) {
+	if lastSeq > room.seq {
+		return nil, false
+	}
+
+	if lastSeq == room.seq {
+		return nil, true
+	}
+
+	if len(room.events) == 0 || room.events[0].Seq > lastSeq+1 {
+		return nil, false
+	}
+
+	start := len(room.events) - int(room.seq-lastSeq)
+	return room.events[start:], true
 }
 
 func (h *WebSocketHub) CreateRoom(sessionID string) error {
@@ -30,6 +71,32 @@ func (h *WebSocketHub) CreateRoom(sessionID string) error {
 	return nil
 }
 
+// DeleteRoom removes a room with its replay buffer. Connected clients get
+// what is queued for them and are then disconnected.
+func (h *WebSocketHub) DeleteRoom(sessionID string) error {
+	h.mu.Lock()
+	room, exists := h.rooms[sessionID]
+	delete(h.rooms, sessionID)
+	h.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("room not found")
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	for playerID, client := range room.clients {
+		delete(room.clients, playerID)
+		close(client.Send)
+	}
+	room.players = make(map[string]bool)
+	room.events = nil
+	room.deleted = true
+
+	return nil
+}
+
 func (h *WebSocketHub) JoinRoom(sessionID string, playerID string) error {
 	h.mu.RLock()
 	room, exists := h.rooms[sessionID]
@@ -64,11 +131,19 @@ func (h *WebSocketHub) LeaveRoom(sessionID string, playerID string) error {
 	room.mu.Lock()
 	defer room.mu.Unlock()
 
-	if _, exists := room.clients[playerID]; !exists {
+	if !room.players[playerID] {
 		return fmt.Errorf("player not in room")
 	}
 
-	delete(room.clients, playerID)
+	delete(room.players, playerID)
+
+	// Closing Send lets the client write what is queued and then disconnect.
+	// Nothing sends to it anymore once it is out of room.clients.
+	if client, ok := room.clients[playerID]; ok {
+		delete(room.clients, playerID)
+		close(client.Send)
+	}
+
 	return nil
 }
 
@@ -81,13 +156,15 @@ func (h *WebSocketHub) BroadcastToRoom(ctx context.Context, sessionID string, me
 		return fmt.Errorf("room not found")
 	}
 
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	message.Seq = room.seq + 1
 	data, err := json.Marshal(message)
 	if err != nil {
 		return err
 	}
-
-	room.mu.RLock()
-	defer room.mu.RUnlock()
+	room.record(message)
 
 	for _, client := range room.clients {
 		select {
@@ -101,3 +178,35 @@ func (h *WebSocketHub) BroadcastToRoom(ctx context.Context, sessionID string, me
 
 	return nil
 }
+
+// NotifyRoom sends a message to every connected client without sequencing or
+// buffering it, for updates like countdown ticks that are stale by the time
+// a client could replay them
+func (h *WebSocketHub) NotifyRoom(ctx context.Context, sessionID string, message models.WSMessage) error {
+	h.mu.RLock()
+	room, exists := h.rooms[sessionID]
+	h.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("room not found")
+	}
+
+	message.Seq = 0
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+
+	for _, client := range room.clients {
+		select {
+		case client.Send <- data:
+		default:
+			// A slow client misses a tick, the next one catches it up
+		}
+	}
+
+	return nil
+}
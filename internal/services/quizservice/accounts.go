@@ -0,0 +1,414 @@
+package quizservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+const (
+	// tokenTTL is how long a player stays signed in. Guests have no other
+	// way back into their account, their tokens last tokenTTL after they
+	// were last used instead.
+	tokenTTL = 30 * 24 * time.Hour
+
+	// maxSignInFailures is how many wrong passwords a client may try per
+	// window before it is locked out
+	maxSignInFailures = 10
+
+	minUsernameLength = 3
+	maxUsernameLength = 32
+	minPasswordLength = 8
+	// bcrypt only hashes the first 72 bytes, longer passwords are refused
+	// rather than cut short
+	maxPasswordBytes = 72
+
+	// missedWordsShown is how many recently missed words a profile lists
+	missedWordsShown = 10
+)
+
+// unknownPlayerHash is compared against when nobody has the username, so a
+// failed sign in takes as long whether or not the account exists
+var unknownPlayerHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("unknown player"), bcrypt.DefaultCost)
+	return hash
+})
+
+// Register creates an account with a password and signs it in
+func (s *QuizService) Register(ctx context.Context, req SignUpRequest) (*SignInResponse, error) {
+	username := strings.TrimSpace(req.Username)
+	if err := validateAccount(username, req.Password); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return s.createAccount(ctx, &models.Account{
+		ID:           uuid.New().String(),
+		Username:     username,
+		PasswordHash: string(hash),
+		CreatedAt:    time.Now(),
+	})
+}
+
+// CreateGuest creates an account without a password. The token returned is
+// the only way back into it until the guest sets one with UpgradeGuest.
+func (s *QuizService) CreateGuest(ctx context.Context, username string) (*SignInResponse, error) {
+	username = strings.TrimSpace(username)
+	if err := validateUsername(username); err != nil {
+		return nil, err
+	}
+
+	return s.createAccount(ctx, &models.Account{
+		ID:        uuid.New().String(),
+		Username:  username,
+		Guest:     true,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *QuizService) createAccount(ctx context.Context, account *models.Account) (*SignInResponse, error) {
+	if err := s.playerRepo.CreatePlayer(ctx, account); err != nil {
+		if errors.Is(err, ErrUsernameTaken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create player: %w", err)
+	}
+
+	return s.signIn(ctx, account)
+}
+
+// Login signs a registered player in with a new token. Like join codes,
+// every attempt is counted before the password is checked and taken back
+// when it was right.
+func (s *QuizService) Login(ctx context.Context, req SignUpRequest) (*SignInResponse, error) {
+	attempts, err := s.playerRepo.CountSignInAttempt(ctx, req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	if attempts > maxSignInFailures {
+		return nil, ErrTooManySignIns
+	}
+
+	account, err := s.playerRepo.FindPlayerByUsername(ctx, strings.TrimSpace(req.Username))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find player: %w", err)
+	}
+
+	hash := unknownPlayerHash()
+	if account != nil && !account.Guest {
+		hash = []byte(account.PasswordHash)
+	}
+
+	if bcrypt.CompareHashAndPassword(hash, []byte(req.Password)) != nil || account == nil || account.Guest {
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.playerRepo.ForgetSignInAttempt(ctx, req.Client); err != nil {
+		return nil, err
+	}
+
+	return s.signIn(ctx, account)
+}
+
+func (s *QuizService) signIn(ctx context.Context, account *models.Account) (*SignInResponse, error) {
+	token, tokenHash, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.playerRepo.SaveToken(ctx, tokenHash, account.ID, tokenTTL); err != nil {
+		return nil, fmt.Errorf("failed to save token: %w", err)
+	}
+
+	return &SignInResponse{Account: account, Token: token}, nil
+}
+
+// UpgradeGuest turns the guest signed in with token into a registered
+// player. The ID stays, so everything played as the guest is kept.
+func (s *QuizService) UpgradeGuest(ctx context.Context, token string, req SignUpRequest) (*models.Account, error) {
+	account, err := s.signedInAccount(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !account.Guest {
+		return nil, ErrNotGuest
+	}
+
+	username := strings.TrimSpace(req.Username)
+	if username == "" {
+		username = account.Username
+	}
+
+	if err := validateAccount(username, req.Password); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	upgraded := *account
+	upgraded.Username = username
+	upgraded.PasswordHash = string(hash)
+	upgraded.Guest = false
+
+	if err := s.playerRepo.UpdatePlayer(ctx, &upgraded); err != nil {
+		if errors.Is(err, ErrUsernameTaken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update player: %w", err)
+	}
+
+	return &upgraded, nil
+}
+
+// Logout ends the sign in of token, other devices stay signed in
+func (s *QuizService) Logout(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrNotSignedIn
+	}
+
+	if err := s.playerRepo.DeleteToken(ctx, hashSecret(token)); err != nil {
+		return fmt.Errorf("failed to delete token: %w", err)
+	}
+
+	return nil
+}
+
+// Me returns the account token is signed in to
+func (s *QuizService) Me(ctx context.Context, token string) (*models.Account, error) {
+	return s.signedInAccount(ctx, token)
+}
+
+func (s *QuizService) signedInAccount(ctx context.Context, token string) (*models.Account, error) {
+	if token == "" {
+		return nil, ErrNotSignedIn
+	}
+
+	playerID, err := s.playerRepo.FindTokenPlayer(ctx, hashSecret(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find token: %w", err)
+	}
+
+	if playerID == "" {
+		return nil, ErrNotSignedIn
+	}
+
+	account, err := s.playerRepo.FindPlayer(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find player: %w", err)
+	}
+
+	if account == nil {
+		return nil, ErrNotSignedIn
+	}
+
+	if account.Guest {
+		if err := s.playerRepo.RefreshToken(ctx, hashSecret(token), tokenTTL); err != nil {
+			return nil, fmt.Errorf("failed to refresh token: %w", err)
+		}
+	}
+
+	return account, nil
+}
+
+// actingPlayer checks that whoever acts as playerID may: accounts have to be
+// signed in with token, other IDs are only known to their player. It returns
+// the account, nil when the ID is not one.
+func (s *QuizService) actingPlayer(ctx context.Context, playerID, token string) (*models.Account, error) {
+	account, err := s.playerRepo.FindPlayer(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find player: %w", err)
+	}
+
+	if account == nil {
+		return nil, nil
+	}
+
+	signedIn, err := s.signedInAccount(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if signedIn.ID != account.ID {
+		return nil, ErrOtherPlayer
+	}
+
+	return account, nil
+}
+
+// joiningPlayer is who joins a session: the signed in player when there is
+// a token, a new player named username otherwise
+func (s *QuizService) joiningPlayer(ctx context.Context, token, username string) (models.Player, error) {
+	if token == "" {
+		return models.Player{ID: uuid.New().String(), Username: username}, nil
+	}
+
+	account, err := s.signedInAccount(ctx, token)
+	if err != nil {
+		return models.Player{}, err
+	}
+
+	return models.Player{ID: account.ID, Username: account.Username}, nil
+}
+
+// Profile sums up the saved results of a registered player. Accounts are
+// kept in Redis but results only in memory with the quizzes, so a restart
+// empties the profile while the account stays.
+func (s *QuizService) Profile(ctx context.Context, playerID string) (*PlayerProfile, error) {
+	account, err := s.playerRepo.FindPlayer(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find player: %w", err)
+	}
+
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	games, err := s.quizReader.GetPlayerResults(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player results: %w", err)
+	}
+
+	practice, err := s.quizReader.GetPlayerPracticeResults(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player results: %w", err)
+	}
+
+	playerRating, err := s.playerRating(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &PlayerProfile{
+		Account:       account,
+		Rating:        playerRating,
+		Games:         len(games),
+		PracticeGames: len(practice),
+		BestScores:    bestScores(games),
+		MissedWords:   []MissedWord{},
+	}
+
+	missed := make([]MissedWord, 0)
+	for _, result := range slices.Concat(games, practice) {
+		for _, answer := range result.Answers {
+			// Questions never asked or answered say nothing about the player
+			if answer.AnsweredAt.IsZero() {
+				continue
+			}
+
+			profile.Answers++
+			profile.AverageTime += answer.AnswerTime
+			if answer.Correct {
+				profile.Correct++
+				continue
+			}
+
+			missed = append(missed, MissedWord{
+				Word:          answer.Word,
+				QuizID:        result.QuizID,
+				Answer:        answer.PlayerChoice,
+				CorrectAnswer: answer.CorrectAnswer,
+				MissedAt:      answer.AnsweredAt,
+			})
+		}
+	}
+
+	if profile.Answers > 0 {
+		profile.Accuracy = float64(profile.Correct) / float64(profile.Answers)
+		profile.AverageTime /= float64(profile.Answers)
+	}
+
+	sort.SliceStable(missed, func(i, j int) bool {
+		return missed[i].MissedAt.After(missed[j].MissedAt)
+	})
+
+	seen := make(map[string]bool)
+	for _, word := range missed {
+		if len(profile.MissedWords) == missedWordsShown {
+			break
+		}
+
+		key := strings.ToLower(word.Word)
+		if word.Word == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		profile.MissedWords = append(profile.MissedWords, word)
+	}
+
+	return profile, nil
+}
+
+// bestScores returns the highest score of each quiz, the earliest one on
+// ties, highest first
+func bestScores(results []*models.QuizResult) []BestScore {
+	best := make(map[string]BestScore)
+	for _, result := range results {
+		if current, ok := best[result.QuizID]; ok && current.Score >= result.FinalScore {
+			continue
+		}
+
+		best[result.QuizID] = BestScore{
+			QuizID:      result.QuizID,
+			SessionID:   result.SessionID,
+			Score:       result.FinalScore,
+			Position:    result.Position,
+			CompletedAt: result.CompletionTime,
+		}
+	}
+
+	scores := make([]BestScore, 0, len(best))
+	for _, score := range best {
+		scores = append(scores, score)
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].QuizID < scores[j].QuizID
+	})
+
+	return scores
+}
+
+func validateAccount(username, password string) error {
+	if err := validateUsername(username); err != nil {
+		return err
+	}
+
+	if utf8.RuneCountInString(password) < minPasswordLength || len(password) > maxPasswordBytes {
+		return fmt.Errorf("%w: password must have at least %d characters and at most %d bytes",
+			ErrInvalidAccount, minPasswordLength, maxPasswordBytes)
+	}
+
+	return nil
+}
+
+func validateUsername(username string) error {
+	if n := utf8.RuneCountInString(username); n < minUsernameLength || n > maxUsernameLength {
+		return fmt.Errorf("%w: username must have %d to %d characters",
+			ErrInvalidAccount, minUsernameLength, maxUsernameLength)
+	}
+
+	return nil
+}
@@ -1,14 +1,358 @@
 package quizservice
 
-import "wordwizardry/internal/pkg/models"
+import (
+	"io"
+	"time"
 
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/pkg/quizimport"
+	"wordwizardry/internal/pkg/quizlint"
+)
+
+// JoinQuizRequest joins as the account Token is signed in to, or as a new
+// player named Username without one
 type JoinQuizRequest struct {
+	QuizID    string `json:"quiz_id"`
+	SessionID string `json:"session_id"` // joins this session instead of starting one
+	Username  string `json:"username"`
+	Token     string `json:"-"`
+}
+
+// PracticeRequest starts a solo session that is not ranked with the others.
+// An adaptive session asks Count questions one at a time, each picked near
+// the player's rating, all of them when Count is zero.
+type PracticeRequest struct {
 	QuizID   string `json:"quiz_id"`
 	Username string `json:"username"`
+	Token    string `json:"-"`
+	Adaptive bool   `json:"adaptive,omitempty"`
+	Count    int    `json:"count,omitempty"`
+}
+
+// QuestionRating is how hard a question turned out to be, next to how hard
+// its author said it is. Computed is empty until enough players answered.
+type QuestionRating struct {
+	QuestionID string            `json:"question_id"`
+	Word       string            `json:"word"`
+	Rating     models.Rating     `json:"rating"`
+	Authored   models.Difficulty `json:"authored,omitempty"`
+	Computed   models.Difficulty `json:"computed,omitempty"`
+}
+
+// ReviewRequest shapes a review quiz. Username is who plays it, only needed
+// to start one. Registered players see and start theirs signed in with Token.
+type ReviewRequest struct {
+	Username string `json:"username"`
+	Token    string `json:"-"`
+	Count    int    `json:"count"`   // due words asked at most, 10 when zero
+	Options  int    `json:"options"` // per question, quizgen.DefaultOptions when zero
+	Seed     int64  `json:"seed"`    // random when zero
+}
+
+// ReviewResponse is a player's review queue with the quiz assembled from it.
+// There is no quiz when nothing is due.
+type ReviewResponse struct {
+	PlayerID  string               `json:"player_id"`
+	Tracked   int                  `json:"tracked"` // words the player has answered
+	Due       []*models.ReviewItem `json:"due"`
+	NextDueAt *time.Time           `json:"next_due_at,omitempty"` // of the first word not due yet
+
+	Seed      int64            `json:"seed,omitempty"`
+	Quiz      *models.Quiz     `json:"quiz,omitempty"`
+	Questions []PlayerQuestion `json:"questions,omitempty"`
+}
+
+// SignUpRequest registers a player, signs one in or sets the password of a
+// guest. Guests keep their username when upgrading without one.
+type SignUpRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Client   string `json:"-"` // address signing in, failed attempts are limited per client
+}
+
+// SignInResponse carries the token the player is signed in with, it is sent
+// back in the X-Player-Token header and cannot be retrieved later
+type SignInResponse struct {
+	Account *models.Account `json:"account"`
+	Token   string          `json:"token"`
+}
+
+// PlayerProfile sums up the saved results of a player. Accuracy and
+// AverageTime count the answers of competitive and practice games.
+type PlayerProfile struct {
+	Account       *models.Account `json:"account"`
+	Rating        models.Rating   `json:"rating"`
+	Games         int             `json:"games"`
+	PracticeGames int             `json:"practice_games"`
+	Answers       int             `json:"answers"`
+	Correct       int             `json:"correct"`
+	Accuracy      float64         `json:"accuracy"`     // share of correct answers
+	AverageTime   float64         `json:"average_time"` // seconds
+	BestScores    []BestScore     `json:"best_scores"`  // highest first
+	MissedWords   []MissedWord    `json:"missed_words"` // most recent first
+}
+
+// BestScore is a player's highest competitive score in a quiz
+type BestScore struct {
+	QuizID      string    `json:"quiz_id"`
+	SessionID   string    `json:"session_id"`
+	Score       int       `json:"score"`
+	Position    int       `json:"position"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// MissedWord is the last wrong or missed answer about a word
+type MissedWord struct {
+	Word          string    `json:"word"`
+	QuizID        string    `json:"quiz_id"`
+	Answer        string    `json:"answer"`
+	CorrectAnswer string    `json:"correct_answer"`
+	MissedAt      time.Time `json:"missed_at"`
+}
+
+type CreateSessionRequest struct {
+	QuizID   string                 `json:"quiz_id"`
+	HostName string                 `json:"host_name"`
+	Settings models.SessionSettings `json:"settings"`
+}
+
+// CreateSessionResponse carries the host key, it cannot be retrieved later.
+// The join code is handed out by the host, session listings leave it out.
+type CreateSessionResponse struct {
+	SessionSummary
+	JoinCode string `json:"join_code"`
+	HostKey  string `json:"host_key"`
+}
+
+// EndSessionRequest ends a session early. The host of a hosted session ends
+// it with HostKey, any other session is ended by one of its players signed
+// in with Token. Player IDs are shared with the room, so they prove nothing.
+type EndSessionRequest struct {
+	HostKey string `json:"-"`
+	Token   string `json:"-"`
+}
+
+type HostAction string
+
+const (
+	HostActionStart  HostAction = "start"
+	HostActionPause  HostAction = "pause"
+	HostActionResume HostAction = "resume"
+	HostActionSkip   HostAction = "skip"
+	HostActionEnd    HostAction = "end"
+	HostActionKick   HostAction = "kick"
+	HostActionMute   HostAction = "mute"
+	HostActionUnmute HostAction = "unmute"
+)
+
+// HostCommand is sent by the host over HTTP or the WebSocket. PlayerID is
+// the target of kick, mute and unmute.
+type HostCommand struct {
+	Action   HostAction `json:"action"`
+	PlayerID string     `json:"player_id,omitempty"`
+}
+
+type WordRequest struct {
+	Lemma        string           `json:"lemma"`
+	PartOfSpeech string           `json:"part_of_speech"`
+	Definitions  []string         `json:"definitions"`
+	Examples     []string         `json:"examples"`
+	Synonyms     []string         `json:"synonyms"`
+	Level        models.CEFRLevel `json:"cefr_level"`
+	Tags         []string         `json:"tags"`
+}
+
+// GenerateQuizRequest picks the words to ask about by tag, level and part of
+// speech, empty fields match every word
+type GenerateQuizRequest struct {
+	QuizID       string           `json:"quiz_id"` // generated when empty
+	Title        string           `json:"title"`   // named after the filter when empty
+	Tag          string           `json:"tag"`
+	Level        models.CEFRLevel `json:"cefr_level"`
+	PartOfSpeech string           `json:"part_of_speech"`
+	Count        int              `json:"count"`   // questions
+	Options      int              `json:"options"` // per question
+	Seed         int64            `json:"seed"`    // random when zero
+	DryRun       bool             `json:"dry_run"`
+}
+
+type GenerateQuizResponse struct {
+	DryRun    bool              `json:"dry_run"`
+	Seed      int64             `json:"seed"`
+	Quiz      *models.Quiz      `json:"quiz"`
+	Questions []models.Question `json:"questions"`
+}
+
+// WordStats sums up the saved answers to questions asking about a word
+type WordStats struct {
+	WordID      string   `json:"word_id"`
+	Lemma       string   `json:"lemma"`
+	Quizzes     []string `json:"quizzes"` // quizzes whose latest version asks about the word
+	Answers     int      `json:"answers"`
+	Correct     int      `json:"correct"`
+	TimedOut    int      `json:"timed_out"`
+	Accuracy    float64  `json:"accuracy"`     // share of correct answers
+	AverageTime float64  `json:"average_time"` // seconds
+}
+
+type SessionSummary struct {
+	ID          string              `json:"id"`
+	QuizID      string              `json:"quiz_id"`
+	QuizVersion int                 `json:"quiz_version"`
+	Phase       models.SessionPhase `json:"phase"`
+	Players     int                 `json:"players"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// JoinByCodeRequest joins the session a code belongs to. Client identifies
+// the caller for guess limiting, e.g. the remote address.
+type JoinByCodeRequest struct {
+	Username string `json:"username"`
+	Token    string `json:"-"`
+	Client   string `json:"-"`
 }
 
 type JoinQuizResponse struct {
-	SessionID string            `json:"session_id"`
-	PlayerID  string            `json:"player_id"`
+	QuizID    string `json:"quiz_id"`
+	SessionID string `json:"session_id"`
+	JoinCode  string `json:"join_code"`
+	PlayerID  string `json:"player_id"`
+	Practice  bool   `json:"practice,omitempty"`
+	Adaptive  bool   `json:"adaptive,omitempty"` // Questions holds only the first one asked
+
+	// Players of hosted sessions answer Questions[CurrentQuestion] once the
+	// host started the session
+	Hosted          bool                `json:"hosted"`
+	Phase           models.SessionPhase `json:"phase"`
+	CurrentQuestion int                 `json:"current_question"`
+
+	// TimeLimit is the seconds per question for questions without their own
+	TimeLimit float64          `json:"time_limit"`
+	Questions []PlayerQuestion `json:"questions"`
+}
+
+// AnswerFeedback tells the player how their answer scored. In practice it
+// also reveals the expected answer with an explanation, and carries the next
+// question of an adaptive session or the result once the last question is
+// answered.
+type AnswerFeedback struct {
+	Correct   bool                `json:"correct"`
+	Points    int                 `json:"points"`
+	TimedOut  bool                `json:"timed_out,omitempty"`
+	Breakdown []models.PointsPart `json:"breakdown,omitempty"`
+
+	CorrectAnswer string             `json:"correct_answer,omitempty"`
+	Explanation   string             `json:"explanation,omitempty"`
+	Grading       *models.Grading    `json:"grading,omitempty"`
+	Result        *models.QuizResult `json:"result,omitempty"`
+	Next          *PlayerQuestion    `json:"next,omitempty"` // adaptive practice, the question asked next
+}
+
+// PlayerQuestion is a question as players see it, without its answer. Which
+// fields are set depends on the type.
+type PlayerQuestion struct {
+	ID        string              `json:"id"`
+	Type      models.QuestionType `json:"type"`
+	Word      string              `json:"word,omitempty"` // hidden when it is the answer
+	Meaning   string              `json:"meaning,omitempty"`
+	Sentence  string              `json:"sentence,omitempty"` // fill_blank
+	Options   []string            `json:"options,omitempty"`  // questions answered by picking one
+	Words     []string            `json:"words,omitempty"`    // match_pairs
+	Meanings  []string            `json:"meanings,omitempty"` // match_pairs, not in the order of Words
+	TimeLimit int                 `json:"time_limit,omitempty"`
+}
+
+type SessionReplayResponse struct {
+	Seq         int64                  `json:"seq"`
+	Session     *models.Session        `json:"session"`
+	Leaderboard []models.SessionPlayer `json:"leaderboard"`
+}
+
+type ImportQuizRequest struct {
+	Format quizimport.Format
+	QuizID string
+	Title  string
+	DryRun bool
+	Data   io.Reader
+}
+
+type ImportQuizResponse struct {
+	DryRun    bool              `json:"dry_run"`
+	Quiz      *models.Quiz      `json:"quiz"`
+	Questions []models.Question `json:"questions"`
+}
+
+type UpdateQuizRequest struct {
+	Title     string `json:"title"`
+	TimeLimit *int   `json:"time_limit"` // seconds per question, 0 restores the default
+	// Scoring replaces the quiz's scoring when set
+	Scoring *models.ScoringConfig `json:"scoring"`
+
+	ShuffleQuestions *bool `json:"shuffle_questions"`
+	ShuffleOptions   *bool `json:"shuffle_options"`
+
+	// Pool replaces the quiz's question pool when set, a pool drawing 0
+	// questions removes it
+	Pool *models.QuestionPool `json:"pool"`
+	// Questions replace the current ones when set, questions without an ID
+	// get one generated
+	Questions []models.Question `json:"questions"`
+}
+
+// QuizDiff lists what changed between two versions of a quiz. Questions are
+// matched by ID.
+type QuizDiff struct {
+	QuizID  string            `json:"quiz_id"`
+	From    int               `json:"from"`
+	To      int               `json:"to"`
+	Fields  []FieldChange     `json:"fields,omitempty"`
+	Added   []models.Question `json:"added,omitempty"`
+	Removed []models.Question `json:"removed,omitempty"`
+	Changed []QuestionChange  `json:"changed,omitempty"`
+}
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type QuestionChange struct {
+	QuestionID string        `json:"question_id"`
+	Fields     []FieldChange `json:"fields"`
+}
+
+type ChangeQuizStatusRequest struct {
+	Version int               `json:"version"` // defaults to the latest version
+	Status  models.QuizStatus `json:"status"`
+	Comment string            `json:"comment"`
+}
+
+type ReviewQuizRequest struct {
+	Version int    `json:"version"` // defaults to the latest version
+	Approve bool   `json:"approve"`
+	Comment string `json:"comment"`
+}
+
+type LintQuizRequest struct {
+	Quiz      *models.Quiz      `json:"quiz"`
 	Questions []models.Question `json:"questions"`
 }
+
+// LintQuizResponse carries every issue found, QuizID and Version are only
+// set when a stored quiz was linted
+type LintQuizResponse struct {
+	QuizID   string           `json:"quiz_id,omitempty"`
+	Version  int              `json:"version,omitempty"`
+	Issues   []quizlint.Issue `json:"issues"`
+	Errors   int              `json:"errors"`
+	Warnings int              `json:"warnings"`
+	Infos    int              `json:"infos"`
+}
+
+// ScheduleQuizRequest times are RFC 3339, OpensAt defaults to now
+type ScheduleQuizRequest struct {
+	OpensAt  *time.Time `json:"opens_at"`
+	ClosesAt *time.Time `json:"closes_at"`
+	StartsAt *time.Time `json:"starts_at"`
+}
@@ -0,0 +1,80 @@
+package quizservice
+
+import (
+	"errors"
+
+	"wordwizardry/internal/pkg/questionpool"
+	"wordwizardry/internal/pkg/quizgen"
+
+	"wordwizardry/internal/services/quizservice/players"
+	"wordwizardry/internal/services/quizservice/quizrepositories"
+	"wordwizardry/internal/services/quizservice/sessions"
+	"wordwizardry/internal/services/quizservice/wordbank"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionFinished = errors.New("session already finished")
+	ErrSessionRunning  = errors.New("session has not finished yet")
+
+	ErrQuizNotFound    = quizrepositories.ErrQuizNotFound
+	ErrQuizExists      = quizrepositories.ErrQuizExists
+	ErrVersionNotFound = quizrepositories.ErrVersionNotFound
+	ErrNotPublished    = quizrepositories.ErrNotPublished
+	ErrStatusConflict  = quizrepositories.ErrStatusConflict
+
+	ErrInvalidTransition = errors.New("quiz status transition not allowed")
+	ErrSelfReview        = errors.New("a quiz cannot be approved by the editor who submitted it")
+
+	ErrQuizNotOpen      = errors.New("quiz is not open")
+	ErrQuizNotStarted   = errors.New("quiz has not started yet")
+	ErrQuizLive         = errors.New("quiz is played live and cannot be practiced")
+	ErrScheduleNotFound = errors.New("quiz has no schedule")
+	ErrInvalidSchedule  = errors.New("invalid schedule")
+
+	ErrJoinCodeNotFound = errors.New("no session with this join code")
+	ErrTooManyAttempts  = errors.New("too many wrong join codes, try again later")
+	ErrTooManySignIns   = errors.New("too many failed sign ins, try again later")
+	ErrJoinByCode       = errors.New("hosted sessions are joined with their join code")
+
+	ErrNotHost            = errors.New("only the host can do this")
+	ErrKicked             = errors.New("kicked from this session by the host")
+	ErrSessionNotStarted  = errors.New("session has not started yet")
+	ErrSessionPaused      = errors.New("session is paused")
+	ErrSessionPhase       = errors.New("not possible in the current session phase")
+	ErrQuestionNotCurrent = errors.New("question is not the current one")
+	ErrQuestionNotFound   = errors.New("question not found in session")
+	ErrQuestionClosed     = errors.New("question time is up")
+	ErrPlayerNotFound     = errors.New("player not found in session")
+	ErrNotInSession       = errors.New("only players of the session can do this")
+	ErrPlayerMuted        = errors.New("player is muted")
+	ErrUnknownHostAction  = errors.New("unknown host action")
+	ErrPracticeSession    = errors.New("not possible in a practice session")
+
+	ErrWordNotFound = errors.New("word not found")
+	ErrWordExists   = wordbank.ErrWordExists
+	ErrWordInUse    = errors.New("word is used by a quiz")
+	ErrInvalidWord  = errors.New("invalid word")
+
+	ErrNothingToReview = errors.New("no words are due for review")
+
+	ErrNotSignedIn        = errors.New("not signed in")
+	ErrInvalidCredentials = errors.New("wrong username or password")
+	ErrInvalidAccount     = errors.New("invalid account")
+	ErrAccountNotFound    = errors.New("player account not found")
+	ErrOtherPlayer        = errors.New("signed in as another player")
+	ErrGuestAccount       = errors.New("guests cannot do this, set a password first")
+	ErrNotEditor          = errors.New("only editors can do this")
+	ErrNotGuest           = errors.New("player is already registered")
+	ErrUsernameTaken      = players.ErrUsernameTaken
+
+	ErrPoolUnsatisfiable = questionpool.ErrUnsatisfiable
+
+	ErrNotEnoughWords = quizgen.ErrNotEnoughWords
+	ErrInvalidOptions = quizgen.ErrInvalidOptions
+
+	ErrSessionFull            = sessions.ErrSessionFull
+	ErrAlreadyAnswered        = sessions.ErrAlreadyAnswered
+	ErrSessionStarted         = errors.New("session already started")
+	ErrInvalidSessionSettings = errors.New("invalid session settings")
+)
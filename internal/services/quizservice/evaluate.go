@@ -0,0 +1,107 @@
+package quizservice
+
+import (
+	"sort"
+	"strings"
+
+	"wordwizardry/internal/pkg/grading"
+	"wordwizardry/internal/pkg/models"
+)
+
+type evaluation struct {
+	choice  string // the answer as recorded
+	correct bool
+	credit  float64         // share of the points earned
+	grading *models.Grading // typed answers
+}
+
+// evaluateAnswer checks a player's answer the way the question's type is
+// answered
+func evaluateAnswer(question models.Question, req SubmitAnswerRequest) evaluation {
+	switch kind := question.Kind(); {
+	case kind == models.QuestionMatchPairs:
+		eval := evaluation{choice: question.FormatMatches(req.Matches), correct: true, credit: 1}
+		for _, pair := range question.Pairs {
+			if !sameText(req.Matches[pair.Word], pair.Meaning) {
+				return evaluation{choice: eval.choice}
+			}
+		}
+		return eval
+
+	case kind.Typed():
+		graded := grading.Grade(req.Answer, question.ExpectedAnswer(), question.Grading)
+		return evaluation{
+			choice:  req.Answer,
+			correct: graded.Credit > 0,
+			credit:  graded.Credit,
+			grading: graded,
+		}
+
+	case kind == models.QuestionTrueFalse:
+		return choiceEvaluation(req.Answer, strings.EqualFold(strings.TrimSpace(req.Answer), question.Correct))
+	}
+
+	// Only the correct option counts, not just any of the listed ones
+	return choiceEvaluation(req.Answer, req.Answer == question.Correct)
+}
+
+func choiceEvaluation(choice string, correct bool) evaluation {
+	eval := evaluation{choice: choice, correct: correct}
+	if correct {
+		eval.credit = 1
+	}
+	return eval
+}
+
+// sameText compares typed text ignoring case and surrounding whitespace
+func sameText(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// playerQuestions turns questions into what a player is shown, in their
+// order and leaving out anything that gives the answer away
+func playerQuestions(questions []models.Question, order *models.PlayerOrder) []PlayerQuestion {
+	var optionOrder map[string][]int
+	if order != nil {
+		optionOrder = order.Options
+	}
+
+	shown := make([]PlayerQuestion, 0, len(questions))
+	for _, q := range orderedQuestions(questions, order) {
+		perm := optionOrder[q.ID]
+
+		pq := PlayerQuestion{
+			ID:        q.ID,
+			Type:      q.Kind(),
+			Word:      q.Word,
+			Meaning:   q.Meaning,
+			TimeLimit: q.TimeLimit,
+		}
+
+		switch pq.Type {
+		case models.QuestionTrueFalse:
+			pq.Options = []string{"true", "false"}
+		case models.QuestionFillBlank:
+			pq.Word = ""
+			pq.Sentence = q.Sentence
+		case models.QuestionSpelling:
+			pq.Word = ""
+		case models.QuestionMatchPairs:
+			for _, pair := range q.Pairs {
+				pq.Words = append(pq.Words, pair.Word)
+				pq.Meanings = append(pq.Meanings, pair.Meaning)
+			}
+			// Listed in pair order the meanings would line up with their words
+			if perm != nil {
+				pq.Meanings = permute(pq.Meanings, perm)
+			} else {
+				sort.Strings(pq.Meanings)
+			}
+		default:
+			pq.Options = permute(q.Options, perm)
+		}
+
+		shown = append(shown, pq)
+	}
+	return shown
+}
@@ -0,0 +1,66 @@
+package quizservice
+
+import (
+	"testing"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+func TestEvaluateAnswer(t *testing.T) {
+	choice := models.Question{
+		Word:    "Cat",
+		Options: []string{"A mammal", "A bird", "A reptile"},
+		Correct: "A mammal",
+	}
+	synonym := models.Question{
+		Type:    models.QuestionSynonym,
+		Word:    "Big",
+		Options: []string{"Large", "Small"},
+		Correct: "Large",
+	}
+	trueFalse := models.Question{Type: models.QuestionTrueFalse, Word: "Cat", Meaning: "A bird", Correct: "false"}
+	spelling := models.Question{Type: models.QuestionSpelling, Word: "platypus", Meaning: "An egg-laying mammal"}
+	pairs := models.Question{
+		Type: models.QuestionMatchPairs,
+		Pairs: []models.MatchPair{
+			{Word: "Cat", Meaning: "Meows"},
+			{Word: "Dog", Meaning: "Barks"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		question models.Question
+		req      SubmitAnswerRequest
+		correct  bool
+	}{
+		{"multiple choice correct option", choice, SubmitAnswerRequest{Answer: "A mammal"}, true},
+		{"multiple choice other listed option", choice, SubmitAnswerRequest{Answer: "A bird"}, false},
+		{"multiple choice unlisted answer", choice, SubmitAnswerRequest{Answer: "A fish"}, false},
+		{"multiple choice is case sensitive", choice, SubmitAnswerRequest{Answer: "a mammal"}, false},
+		{"synonym correct option", synonym, SubmitAnswerRequest{Answer: "Large"}, true},
+		{"synonym other listed option", synonym, SubmitAnswerRequest{Answer: "Small"}, false},
+		{"true false ignores case", trueFalse, SubmitAnswerRequest{Answer: " False"}, true},
+		{"true false wrong", trueFalse, SubmitAnswerRequest{Answer: "true"}, false},
+		{"spelling falls back to the word", spelling, SubmitAnswerRequest{Answer: "Platypus"}, true},
+		{"spelling wrong word", spelling, SubmitAnswerRequest{Answer: "wombat"}, false},
+		{"pairs all matched", pairs, SubmitAnswerRequest{Matches: map[string]string{"Cat": "meows", "Dog": "Barks"}}, true},
+		{"pairs swapped", pairs, SubmitAnswerRequest{Matches: map[string]string{"Cat": "Barks", "Dog": "Meows"}}, false},
+		{"pairs missing one", pairs, SubmitAnswerRequest{Matches: map[string]string{"Cat": "Meows"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := evaluateAnswer(tt.question, tt.req)
+			if eval.correct != tt.correct {
+				t.Errorf("correct = %v, want %v", eval.correct, tt.correct)
+			}
+			if tt.correct && eval.credit != 1 {
+				t.Errorf("credit = %v, want 1", eval.credit)
+			}
+			if !tt.correct && eval.credit != 0 {
+				t.Errorf("credit = %v, want 0", eval.credit)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package quizservice
+
+import (
+	"context"
+	"fmt"
+
+	"wordwizardry/internal/pkg/export"
+	"wordwizardry/internal/pkg/models"
+)
+
+// SessionExportRows builds one row per player and question from the live
+// session state. Until the session finished only its host can export it,
+// and without the answer key.
+func (s *QuizService) SessionExportRows(ctx context.Context, sessionID, hostKey string) ([]export.Row, error) {
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	live, err := liveSessionAccess(session, hostKey)
+	if err != nil {
+		return nil, err
+	}
+
+	questions := session.Questions
+	if live {
+		questions = withoutAnswerKey(questions)
+	}
+
+	rows := make([]export.Row, 0, len(session.Players)*len(questions))
+	for _, player := range session.Players {
+		for _, question := range questions {
+			answer, ok := session.Result[models.ResultKey(question.ID, player.ID)]
+			if !ok && session.Adaptive {
+				// never picked, so never asked
+				continue
+			}
+			if !ok {
+				answer = models.Answer{CorrectAnswer: question.ExpectedAnswer()}
+			}
+			if live {
+				answer.CorrectAnswer = ""
+			}
+
+			rows = append(rows, exportRow(session.Quiz.ID, session.ID, player.Player, models.ResultAnswer{
+				QuestionID: question.ID,
+				Word:       question.Word,
+				Answer:     answer,
+			}))
+		}
+	}
+
+	return rows, nil
+}
+
+// QuizExportRows builds rows from the saved results of every finished
+// session of a quiz
+func (s *QuizService) QuizExportRows(ctx context.Context, quizID string) ([]export.Row, error) {
+	results, err := s.QuizResults(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []export.Row
+	for _, result := range results {
+		player := models.Player{ID: result.PlayerID, Username: result.Username}
+		for _, answer := range result.Answers {
+			rows = append(rows, exportRow(result.QuizID, result.SessionID, player, answer))
+		}
+	}
+
+	return rows, nil
+}
+
+func exportRow(quizID, sessionID string, player models.Player, answer models.ResultAnswer) export.Row {
+	return export.Row{
+		QuizID:        quizID,
+		SessionID:     sessionID,
+		PlayerID:      player.ID,
+		Player:        player.Username,
+		QuestionID:    answer.QuestionID,
+		Question:      answer.Word,
+		Choice:        answer.PlayerChoice,
+		CorrectAnswer: answer.CorrectAnswer,
+		Correct:       answer.Correct,
+		Points:        answer.Points,
+		ResponseTime:  answer.AnswerTime,
+	}
+}
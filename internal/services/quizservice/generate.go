@@ -0,0 +1,81 @@
+package quizservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"wordwizardry/internal/pkg/quizgen"
+	"wordwizardry/internal/services/quizservice/wordbank"
+)
+
+// GenerateQuiz builds a quiz from the words matching the filter, with wrong
+// options taken from the whole word bank, and writes it through the
+// QuizWriter as a draft. A dry run only generates. The seed in the response
+// reproduces the quiz while the word bank is unchanged.
+func (s *QuizService) GenerateQuiz(ctx context.Context, req GenerateQuizRequest) (*GenerateQuizResponse, error) {
+	filter := wordbank.WordFilter{
+		Tag:          req.Tag,
+		Level:        req.Level,
+		PartOfSpeech: req.PartOfSpeech,
+	}
+
+	targets, err := s.Words(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := s.Words(ctx, wordbank.WordFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = generatedTitle(filter)
+	}
+
+	generated, err := quizgen.Generate(targets, pool, quizgen.Options{
+		QuizID:  req.QuizID,
+		Title:   title,
+		Count:   req.Count,
+		Options: req.Options,
+		Seed:    req.Seed,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &GenerateQuizResponse{
+		DryRun:    req.DryRun,
+		Seed:      generated.Seed,
+		Quiz:      generated.Quiz,
+		Questions: generated.Questions,
+	}
+
+	if req.DryRun {
+		return resp, nil
+	}
+
+	if err := s.quizWriter.CreateQuiz(ctx, generated.Quiz, generated.Questions); err != nil {
+		return nil, fmt.Errorf("failed to create quiz: %w", err)
+	}
+
+	return resp, nil
+}
+
+// generatedTitle names a quiz after the filter it was generated from, e.g.
+// "Vocabulary: B2 animals"
+func generatedTitle(filter wordbank.WordFilter) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{string(filter.Level), filter.Tag, filter.PartOfSpeech} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "Vocabulary"
+	}
+	return "Vocabulary: " + strings.Join(parts, " ")
+}
@@ -0,0 +1,371 @@
+package quizservice
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+const (
+	// HostClientID is the room member the host connects as. Player IDs are
+	// UUIDs so it cannot clash with a player.
+	HostClientID = "host"
+
+	// maxChatLength is how many characters of a chat message are kept
+	maxChatLength = 200
+)
+
+// AuthorizeHost checks the host key of a hosted session
+func (s *QuizService) AuthorizeHost(ctx context.Context, sessionID, hostKey string) (*models.Session, error) {
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	if !hostKeyMatches(session, hostKey) {
+		return nil, ErrNotHost
+	}
+
+	return session, nil
+}
+
+func hostKeyMatches(session *models.Session, hostKey string) bool {
+	return session.Hosted() && hostKey != "" &&
+		subtle.ConstantTimeCompare([]byte(hashSecret(hostKey)), []byte(session.HostKeyHash)) == 1
+}
+
+// RunHostCommand carries out a host action on a session. Callers must have
+// authorized the host with AuthorizeHost.
+func (s *QuizService) RunHostCommand(ctx context.Context, sessionID string, cmd HostCommand) error {
+	switch cmd.Action {
+	case HostActionStart:
+		return s.changeSessionPhase(ctx, sessionID, models.SessionPhaseInProgress, "quiz_started", models.SessionPhaseLobby)
+	case HostActionPause:
+		return s.changeSessionPhase(ctx, sessionID, models.SessionPhasePaused, "quiz_paused", models.SessionPhaseInProgress)
+	case HostActionResume:
+		return s.changeSessionPhase(ctx, sessionID, models.SessionPhaseInProgress, "quiz_resumed", models.SessionPhasePaused)
+	case HostActionSkip:
+		return s.skipQuestion(ctx, sessionID)
+	case HostActionEnd:
+		_, err := s.EndSession(ctx, sessionID, EndReasonHostEnded)
+		return err
+	case HostActionKick:
+		return s.kickPlayer(ctx, sessionID, cmd.PlayerID)
+	case HostActionMute, HostActionUnmute:
+		return s.mutePlayer(ctx, sessionID, cmd.PlayerID, cmd.Action == HostActionMute)
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnknownHostAction, cmd.Action)
+}
+
+// SendChat broadcasts a chat message from a player, or from the host when
+// playerID is HostClientID
+func (s *QuizService) SendChat(ctx context.Context, sessionID, playerID, text string) error {
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil {
+		return ErrSessionNotFound
+	}
+
+	username := session.HostName
+	if playerID != HostClientID {
+		player := findSessionPlayer(session, playerID)
+		if player == nil {
+			return ErrPlayerNotFound
+		}
+		if player.Muted {
+			return ErrPlayerMuted
+		}
+		username = player.Username
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(text) > maxChatLength {
+		text = string([]rune(text)[:maxChatLength])
+	}
+
+	return s.hub.BroadcastToRoom(ctx, sessionID, models.WSMessage{
+		Type: "chat_message",
+		Data: map[string]interface{}{
+			"player_id": playerID,
+			"username":  username,
+			"text":      text,
+		},
+	})
+}
+
+// changeSessionPhase moves the session between phases and tells the room
+func (s *QuizService) changeSessionPhase(ctx context.Context, sessionID string, to models.SessionPhase, messageType string, from ...models.SessionPhase) error {
+	changed, err := s.sessionManager.TransitionQuizSessionPhase(ctx, sessionID, to, messageType, from...)
+	if err != nil {
+		return fmt.Errorf("failed to change session phase: %w", err)
+	}
+
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil {
+		return ErrSessionNotFound
+	}
+
+	if !changed {
+		return phaseError(session.Phase)
+	}
+
+	question := session.Questions[session.CurrentQuestion]
+	err = s.hub.BroadcastToRoom(ctx, sessionID, models.WSMessage{
+		Type: messageType,
+		Data: map[string]interface{}{
+			"phase":          to,
+			"question_index": session.CurrentQuestion,
+			"question_id":    question.ID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	switch to {
+	case models.SessionPhasePaused:
+		return s.stopQuestionTimer(ctx, session)
+	case models.SessionPhaseInProgress:
+		// A resumed question continues with the time it had left
+		seconds := session.TimeRemaining
+		if seconds <= 0 {
+			seconds = questionTimeLimit(session.Quiz, question)
+		}
+		return s.openQuestion(ctx, session, session.CurrentQuestion, seconds)
+	}
+
+	return nil
+}
+
+func (s *QuizService) skipQuestion(ctx context.Context, sessionID string) error {
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil {
+		return ErrSessionNotFound
+	}
+
+	if session.Phase != models.SessionPhaseInProgress {
+		return phaseError(session.Phase)
+	}
+
+	return s.advanceQuestion(ctx, sessionID, session.CurrentQuestion, true)
+}
+
+// advanceQuestion moves a hosted session from question from to the next one,
+// or ends it after the last. It does nothing when the session already moved
+// on, so a skip racing the last answer advances only once.
+func (s *QuizService) advanceQuestion(ctx context.Context, sessionID string, from int, skipped bool) error {
+	s.advanceMu.Lock()
+	defer s.advanceMu.Unlock()
+
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil || session.CurrentQuestion != from || session.Phase == models.SessionPhaseFinished {
+		return nil
+	}
+
+	next := from + 1
+	if next >= len(session.Questions) {
+		_, err := s.EndSession(ctx, sessionID, EndReasonCompleted)
+		if err != nil && !errors.Is(err, ErrSessionFinished) {
+			return err
+		}
+		return nil
+	}
+
+	err = s.sessionManager.AppendQuizSessionEvents(ctx, sessionID, models.SessionEvent{
+		Type:          models.SessionEventQuestionChanged,
+		QuestionIndex: next,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to change question: %w", err)
+	}
+
+	err = s.hub.BroadcastToRoom(ctx, sessionID, models.WSMessage{
+		Type: "question_changed",
+		Data: map[string]interface{}{
+			"question_index": next,
+			"question_id":    session.Questions[next].ID,
+			"skipped":        skipped,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.openQuestion(ctx, session, next, questionTimeLimit(session.Quiz, session.Questions[next]))
+}
+
+// kickPlayer removes a player from the session and closes their connection
+func (s *QuizService) kickPlayer(ctx context.Context, sessionID, playerID string) error {
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil {
+		return ErrSessionNotFound
+	}
+
+	player := findSessionPlayer(session, playerID)
+	if player == nil {
+		return ErrPlayerNotFound
+	}
+
+	if err := s.sessionManager.RemovePlayerFromQuizSession(ctx, sessionID, playerID, models.RemovedKicked); err != nil {
+		return err
+	}
+
+	// Queued before leaving the room, so it is the last thing the player gets
+	notice := models.WSMessage{Type: "kicked", Data: map[string]interface{}{"player_id": playerID}}
+	if err := s.hub.SendToPlayer(ctx, sessionID, playerID, notice); err != nil {
+		log.Printf("failed to notify kicked player: %v", err)
+	}
+
+	if err := s.hub.LeaveRoom(sessionID, playerID); err != nil {
+		log.Printf("failed to remove kicked player from room: %v", err)
+	}
+
+	err = s.hub.BroadcastToRoom(ctx, sessionID, models.WSMessage{
+		Type: "player_kicked",
+		Data: map[string]interface{}{
+			"player_id": playerID,
+			"username":  player.Username,
+			"count":     len(session.Players) - 1,
+		},
+	})
+	if err != nil {
+		log.Printf("failed to broadcast kick: %v", err)
+	}
+
+	// The kicked player may have been the last one the question waited for
+	if session.Phase == models.SessionPhaseInProgress {
+		s.progressSession(ctx, sessionID)
+	}
+
+	return nil
+}
+
+func (s *QuizService) mutePlayer(ctx context.Context, sessionID, playerID string, muted bool) error {
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil {
+		return ErrSessionNotFound
+	}
+
+	if findSessionPlayer(session, playerID) == nil {
+		return ErrPlayerNotFound
+	}
+
+	err = s.sessionManager.AppendQuizSessionEvents(ctx, sessionID, models.SessionEvent{
+		Type:     models.SessionEventPlayerMuted,
+		PlayerID: playerID,
+		Muted:    muted,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mute player: %w", err)
+	}
+
+	return s.hub.BroadcastToRoom(ctx, sessionID, models.WSMessage{
+		Type: "player_muted",
+		Data: map[string]interface{}{
+			"player_id": playerID,
+			"muted":     muted,
+		},
+	})
+}
+
+func phaseError(phase models.SessionPhase) error {
+	if phase == models.SessionPhaseFinished {
+		return ErrSessionFinished
+	}
+	return fmt.Errorf("%w: session is %s", ErrSessionPhase, phase)
+}
+
+func findSessionPlayer(session *models.Session, playerID string) *models.SessionPlayer {
+	for i := range session.Players {
+		if session.Players[i].ID == playerID {
+			return &session.Players[i]
+		}
+	}
+	return nil
+}
+
+// newSecret returns a random host key or player token and the hash that is
+// stored
+func newSecret() (secret, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	secret = hex.EncodeToString(b)
+	return secret, hashSecret(secret), nil
+}
+
+func hashSecret(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// RequestEndSession ends a session on request. Hosted sessions can only be
+// ended with their host key, others by one of their players signed in.
+func (s *QuizService) RequestEndSession(ctx context.Context, sessionID string, req EndSessionRequest) ([]*models.QuizResult, error) {
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	if session.Hosted() {
+		if _, err := s.AuthorizeHost(ctx, sessionID, req.HostKey); err != nil {
+			return nil, err
+		}
+	} else {
+		account, err := s.signedInAccount(ctx, req.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		if findSessionPlayer(session, account.ID) == nil {
+			return nil, ErrNotInSession
+		}
+	}
+
+	return s.EndSession(ctx, sessionID, EndReasonHostEnded)
+}
@@ -0,0 +1,60 @@
+package quizservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"wordwizardry/internal/pkg/quizimport"
+)
+
+// ImportQuiz parses a quiz file and writes it through the QuizWriter. Invalid
+// rows are reported together as quizimport.Errors and nothing is written. A
+// dry run only parses and validates. Rows referencing the word bank get
+// their text from it before they are validated.
+func (s *QuizService) ImportQuiz(ctx context.Context, req ImportQuizRequest) (*ImportQuizResponse, error) {
+	parsed, err := quizimport.Decode(req.Data, req.Format, quizimport.Options{
+		QuizID: req.QuizID,
+		Title:  req.Title,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var errs quizimport.Errors
+	for i := range parsed.Questions {
+		q := &parsed.Questions[i]
+		if err := s.resolveWord(ctx, q); err != nil {
+			if !errors.Is(err, ErrWordNotFound) {
+				return nil, err
+			}
+			errs = append(errs, quizimport.LineError{
+				Line:    parsed.Lines[q.ID],
+				Message: fmt.Sprintf("unknown word_id %q", q.WordID),
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if err := parsed.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp := &ImportQuizResponse{
+		DryRun:    req.DryRun,
+		Quiz:      parsed.Quiz,
+		Questions: parsed.Questions,
+	}
+
+	if req.DryRun {
+		return resp, nil
+	}
+
+	if err := s.quizWriter.CreateQuiz(ctx, parsed.Quiz, parsed.Questions); err != nil {
+		return nil, fmt.Errorf("failed to create quiz: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,54 @@
+package quizservice
+
+import (
+	"context"
+	"fmt"
+
+	"wordwizardry/internal/services/quizservice/sessions"
+)
+
+// maxJoinCodeFailures is how many wrong codes a client may try per window
+// before it is locked out. With a million codes guessing a live one stays
+// impractical.
+const maxJoinCodeFailures = 10
+
+// JoinByCode adds a player to the session a join code belongs to. Every
+// lookup is counted before it is made, so concurrent guesses cannot get past
+// the limit, and taken back when the code was right.
+func (s *QuizService) JoinByCode(ctx context.Context, code string, req JoinByCodeRequest) (*JoinQuizResponse, error) {
+	attempts, err := s.sessionManager.CountJoinCodeAttempt(ctx, req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	if attempts > maxJoinCodeFailures {
+		return nil, ErrTooManyAttempts
+	}
+
+	code = sessions.NormalizeJoinCode(code)
+
+	var sessionID string
+	if sessions.IsJoinCode(code) {
+		session, err := s.sessionManager.FindQuizSessionByJoinCode(ctx, code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find session: %w", err)
+		}
+		if session != nil {
+			sessionID = session.ID
+		}
+	}
+
+	if sessionID == "" {
+		return nil, ErrJoinCodeNotFound
+	}
+
+	if err := s.sessionManager.ForgetJoinCodeAttempt(ctx, req.Client); err != nil {
+		return nil, err
+	}
+
+	return s.joinQuiz(ctx, JoinQuizRequest{
+		SessionID: sessionID,
+		Username:  req.Username,
+		Token:     req.Token,
+	}, true)
+}
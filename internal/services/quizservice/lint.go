@@ -0,0 +1,41 @@
+package quizservice
+
+import (
+	"context"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/pkg/quizlint"
+)
+
+// LintQuiz runs the quizlint rules against a stored version, the latest one
+// when version is zero
+func (s *QuizService) LintQuiz(ctx context.Context, quizID string, version int) (*LintQuizResponse, error) {
+	v, err := s.workflowVersion(ctx, quizID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	response := lintResponse(&v.Quiz, v.Questions)
+	response.QuizID = v.Quiz.ID
+	response.Version = v.Quiz.Version
+	return response, nil
+}
+
+// LintQuizContent lints a quiz that has not been saved yet
+func (s *QuizService) LintQuizContent(req LintQuizRequest) *LintQuizResponse {
+	quiz := req.Quiz
+	if quiz == nil {
+		quiz = &models.Quiz{}
+	}
+	return lintResponse(quiz, req.Questions)
+}
+
+func lintResponse(quiz *models.Quiz, questions []models.Question) *LintQuizResponse {
+	report := quizlint.Lint(quiz, questions)
+	return &LintQuizResponse{
+		Issues:   report.Issues,
+		Errors:   report.Count(quizlint.SeverityError),
+		Warnings: report.Count(quizlint.SeverityWarning),
+		Infos:    report.Count(quizlint.SeverityInfo),
+	}
+}
@@ -0,0 +1,128 @@
+package quizservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice/sessions"
+)
+
+// SetReady toggles a player's ready-check in the lobby. The session starts
+// as soon as the configured minimum of players is ready. Players with an
+// account have to be signed in to it with token.
+func (s *QuizService) SetReady(ctx context.Context, sessionID, playerID, token string, ready bool) error {
+	if _, err := s.actingPlayer(ctx, playerID, token); err != nil {
+		return err
+	}
+
+	session, err := s.sessionManager.FindQuizPlayerSession(ctx, sessionID, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil {
+		return ErrPlayerNotFound
+	}
+
+	if session.Phase != models.SessionPhaseLobby {
+		return phaseError(session.Phase)
+	}
+
+	err = s.sessionManager.AppendQuizSessionEvents(ctx, sessionID, models.SessionEvent{
+		Type:     models.SessionEventPlayerReady,
+		PlayerID: playerID,
+		Ready:    ready,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set ready: %w", err)
+	}
+
+	readyCount := 0
+	for _, player := range session.Players {
+		if player.ID == playerID {
+			player.Ready = ready
+		}
+		if player.Ready {
+			readyCount++
+		}
+	}
+
+	err = s.hub.BroadcastToRoom(ctx, sessionID, models.WSMessage{
+		Type: "player_ready",
+		Data: map[string]interface{}{
+			"player_id":    playerID,
+			"ready":        ready,
+			"ready_count":  readyCount,
+			"player_count": len(session.Players),
+		},
+	})
+	if err != nil {
+		log.Printf("failed to broadcast ready-check: %v", err)
+	}
+
+	if minPlayers := session.Settings.MinPlayers; minPlayers > 0 && readyCount >= minPlayers {
+		s.autoStart(ctx, sessionID)
+	}
+
+	return nil
+}
+
+// scheduleLobbyTimeout has the scheduler start the session LobbyTimeout
+// after the first player joined, however many are ready by then. It is
+// called on every join, only the first one starts the countdown.
+func (s *QuizService) scheduleLobbyTimeout(ctx context.Context, session *models.Session) error {
+	if session.Settings.LobbyTimeout <= 0 {
+		return nil
+	}
+
+	startsAt := time.Now().Add(time.Duration(session.Settings.LobbyTimeout) * time.Second).Truncate(time.Millisecond)
+
+	added, err := s.sessionManager.AddDeadline(ctx, sessions.Deadline{
+		Kind:      sessions.DeadlineLobby,
+		SessionID: session.ID,
+		At:        startsAt,
+	})
+	if err != nil || !added {
+		return err
+	}
+
+	err = s.hub.BroadcastToRoom(ctx, session.ID, models.WSMessage{
+		Type: "lobby_countdown",
+		Data: map[string]interface{}{
+			"starts_at": startsAt,
+		},
+	})
+	if err != nil {
+		log.Printf("failed to broadcast lobby countdown: %v", err)
+	}
+
+	return nil
+}
+
+// autoStart starts a session still in the lobby. Losing the race against
+// the host or another trigger is not an error.
+func (s *QuizService) autoStart(ctx context.Context, sessionID string) {
+	err := s.changeSessionPhase(ctx, sessionID, models.SessionPhaseInProgress, "quiz_started", models.SessionPhaseLobby)
+	if err != nil && !errors.Is(err, ErrSessionPhase) && !errors.Is(err, ErrSessionFinished) {
+		log.Printf("failed to start session %s: %v", sessionID, err)
+	}
+}
+
+func validateSessionSettings(settings models.SessionSettings) error {
+	switch {
+	case settings.MinPlayers < 0 || settings.MaxPlayers < 0 || settings.LobbyTimeout < 0:
+		return fmt.Errorf("%w: limits cannot be negative", ErrInvalidSessionSettings)
+	case settings.MaxPlayers > 0 && settings.MinPlayers > settings.MaxPlayers:
+		return fmt.Errorf("%w: min_players is above max_players", ErrInvalidSessionSettings)
+	}
+
+	switch settings.LateJoin {
+	case "", models.LateJoinAllow, models.LateJoinDeny:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown late_join policy %q", ErrInvalidSessionSettings, settings.LateJoin)
+}
@@ -0,0 +1,39 @@
+package players
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+var ErrUsernameTaken = errors.New("username is taken")
+
+// PlayerRepository keeps player accounts and the tokens they are signed in
+// with. Usernames of registered accounts are unique regardless of case,
+// guests only go by theirs.
+type PlayerRepository interface {
+	// return nil when there is no such player
+	FindPlayer(ctx context.Context, id string) (*models.Account, error)
+	FindPlayerByUsername(ctx context.Context, username string) (*models.Account, error)
+	// ErrUsernameTaken when another account has the username
+	CreatePlayer(ctx context.Context, account *models.Account) error
+	// replaces the account, ErrUsernameTaken when it registers or is renamed
+	// with a taken username
+	UpdatePlayer(ctx context.Context, account *models.Account) error
+
+	// tokens are only stored hashed and expire after ttl
+	SaveToken(ctx context.Context, tokenHash, playerID string, ttl time.Duration) error
+	// returns "" when the token is unknown or expired
+	FindTokenPlayer(ctx context.Context, tokenHash string) (string, error)
+	// restarts the ttl of a token, nothing when it is unknown or expired
+	RefreshToken(ctx context.Context, tokenHash string, ttl time.Duration) error
+	DeleteToken(ctx context.Context, tokenHash string) error
+
+	// counts a sign in attempt of the client and returns how many it made
+	// in the current window, shared by every server. Attempts that signed
+	// in are forgotten again.
+	CountSignInAttempt(ctx context.Context, client string) (int64, error)
+	ForgetSignInAttempt(ctx context.Context, client string) error
+}
@@ -0,0 +1,220 @@
+package redisplayerrepository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice/players"
+)
+
+// Redis key patterns
+const (
+	playersKey   = "players"           // Hash: player ID -> JSON encoded account, never expires
+	usernamesKey = "players:usernames" // Hash: lower case username -> ID of the registered player, never expires
+	tokenKey     = "player:token:%s"   // String: player ID, by token hash, expires with the token
+
+	attemptsKey   = "players:signin:attempts:%s" // String: Sign in attempts of a client that failed
+	attemptWindow = 10 * time.Minute             // Attempts are counted over this window
+)
+
+// forgetAttemptScript takes back a counted sign in attempt. A window that
+// expired in between is not started again.
+//
+// KEYS[1] attempts key
+var forgetAttemptScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.call('DECR', KEYS[1])
+end
+return 0
+`)
+
+// account is what is stored, models.Account leaves the hash out of JSON so
+// it is never sent to clients
+type account struct {
+	models.Account
+	PasswordHash string `json:"password_hash,omitempty"`
+}
+
+type RedisPlayerRepository struct {
+	rdb *redis.Client
+}
+
+var _ players.PlayerRepository = (*RedisPlayerRepository)(nil)
+
+func NewRedisPlayerRepository(redisURL string) (*RedisPlayerRepository, error) {
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
+	}
+
+	rdb := redis.NewClient(opt)
+
+	ctx := context.Background()
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisPlayerRepository{rdb: rdb}, nil
+}
+
+func (r *RedisPlayerRepository) FindPlayer(ctx context.Context, id string) (*models.Account, error) {
+	data, err := r.rdb.HGet(ctx, playersKey, id).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var stored account
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode player: %w", err)
+	}
+
+	stored.Account.PasswordHash = stored.PasswordHash
+	return &stored.Account, nil
+}
+
+func (r *RedisPlayerRepository) FindPlayerByUsername(ctx context.Context, username string) (*models.Account, error) {
+	id, err := r.rdb.HGet(ctx, usernamesKey, strings.ToLower(username)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return r.FindPlayer(ctx, id)
+}
+
+func (r *RedisPlayerRepository) CreatePlayer(ctx context.Context, player *models.Account) error {
+	if !player.Guest {
+		if err := r.claimUsername(ctx, player); err != nil {
+			return err
+		}
+	}
+
+	return r.savePlayer(ctx, player)
+}
+
+func (r *RedisPlayerRepository) UpdatePlayer(ctx context.Context, player *models.Account) error {
+	current, err := r.FindPlayer(ctx, player.ID)
+	if err != nil {
+		return err
+	}
+
+	if player.Guest || (current != nil && !current.Guest && strings.EqualFold(current.Username, player.Username)) {
+		return r.savePlayer(ctx, player)
+	}
+
+	// The new username is claimed before the old one is given up, so a failed
+	// rename leaves the account as it was
+	if err := r.claimUsername(ctx, player); err != nil {
+		return err
+	}
+
+	if current != nil && !current.Guest {
+		if err := r.rdb.HDel(ctx, usernamesKey, strings.ToLower(current.Username)).Err(); err != nil {
+			return fmt.Errorf("failed to release username: %w", err)
+		}
+	}
+
+	return r.savePlayer(ctx, player)
+}
+
+func (r *RedisPlayerRepository) claimUsername(ctx context.Context, player *models.Account) error {
+	claimed, err := r.rdb.HSetNX(ctx, usernamesKey, strings.ToLower(player.Username), player.ID).Result()
+	if err != nil {
+		return fmt.Errorf("failed to claim username: %w", err)
+	}
+
+	if !claimed {
+		return players.ErrUsernameTaken
+	}
+
+	return nil
+}
+
+func (r *RedisPlayerRepository) savePlayer(ctx context.Context, player *models.Account) error {
+	data, err := json.Marshal(account{Account: *player, PasswordHash: player.PasswordHash})
+	if err != nil {
+		return fmt.Errorf("failed to encode player: %w", err)
+	}
+
+	if err := r.rdb.HSet(ctx, playersKey, player.ID, data).Err(); err != nil {
+		return fmt.Errorf("failed to store player: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RedisPlayerRepository) SaveToken(ctx context.Context, tokenHash, playerID string, ttl time.Duration) error {
+	if err := r.rdb.Set(ctx, fmt.Sprintf(tokenKey, tokenHash), playerID, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RedisPlayerRepository) FindTokenPlayer(ctx context.Context, tokenHash string) (string, error) {
+	playerID, err := r.rdb.Get(ctx, fmt.Sprintf(tokenKey, tokenHash)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return playerID, nil
+}
+
+func (r *RedisPlayerRepository) RefreshToken(ctx context.Context, tokenHash string, ttl time.Duration) error {
+	if err := r.rdb.Expire(ctx, fmt.Sprintf(tokenKey, tokenHash), ttl).Err(); err != nil {
+		return fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RedisPlayerRepository) DeleteToken(ctx context.Context, tokenHash string) error {
+	if err := r.rdb.Del(ctx, fmt.Sprintf(tokenKey, tokenHash)).Err(); err != nil {
+		return fmt.Errorf("failed to delete token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RedisPlayerRepository) CountSignInAttempt(ctx context.Context, client string) (int64, error) {
+	key := fmt.Sprintf(attemptsKey, client)
+
+	var incr *redis.IntCmd
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		// The window starts at the first attempt and is not extended
+		pipe.ExpireNX(ctx, key, attemptWindow)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count sign in attempt: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *RedisPlayerRepository) ForgetSignInAttempt(ctx context.Context, client string) error {
+	err := forgetAttemptScript.Run(ctx, r.rdb, []string{fmt.Sprintf(attemptsKey, client)}).Err()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to forget sign in attempt: %w", err)
+	}
+
+	return nil
+}
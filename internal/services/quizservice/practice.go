@@ -0,0 +1,180 @@
+package quizservice
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+// StartPractice starts a private session of the published version of a quiz
+// for one player. It runs without a room: every answer is checked and
+// explained in the response to SubmitAnswer, and the result is saved with
+// the practice results when the last question is answered. An adaptive
+// session hands out one question at a time, the first one here and each next
+// one with the feedback on the answer before.
+func (s *QuizService) StartPractice(ctx context.Context, req PracticeRequest) (*JoinQuizResponse, error) {
+	quiz, questions, err := s.openQuiz(ctx, req.QuizID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Practicing a live quiz before it is played would give its questions away
+	schedule, err := s.scheduleStore.FindSchedule(ctx, req.QuizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find schedule: %w", err)
+	}
+
+	if schedule != nil && schedule.StartsAt != nil {
+		return nil, ErrQuizLive
+	}
+
+	// Nor can it be practiced while others are playing it, practice sessions
+	// are not indexed so every active session is a live one
+	active, err := s.sessionManager.FindActiveQuizSessions(ctx, req.QuizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+
+	if len(active) > 0 {
+		return nil, ErrQuizLive
+	}
+
+	questions, drawSeed, err := s.drawQuestions(ctx, quiz, questions)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := s.joiningPlayer(ctx, req.Token, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	askCount := 0
+	if req.Adaptive {
+		askCount = len(questions)
+		if req.Count > 0 {
+			askCount = min(req.Count, askCount)
+		}
+	}
+
+	return s.startPractice(ctx, quiz, questions, drawSeed, player, askCount)
+}
+
+// startPractice creates the practice session of the questions for player.
+// With an askCount it is adaptive and asks that many of them.
+func (s *QuizService) startPractice(ctx context.Context, quiz *models.Quiz, questions []models.Question, drawSeed int64, player models.Player, askCount int) (*JoinQuizResponse, error) {
+	session := &models.Session{
+		Quiz:      quiz,
+		Phase:     models.SessionPhaseInProgress,
+		Questions: questions,
+		Players:   []models.SessionPlayer{},
+		Result:    make(map[string]models.Answer),
+		DrawSeed:  drawSeed,
+		Practice:  true,
+	}
+
+	if askCount > 0 {
+		first, err := s.nearestQuestion(ctx, quiz.ID, player.ID, questions, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		session.Adaptive = true
+		session.AskCount = askCount
+		session.CurrentQuestion = first
+	}
+
+	if err := s.sessionManager.CreateQuizSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	sessionPlayer := models.SessionPlayer{
+		Player: player,
+		QuizID: quiz.ID,
+		Order:  newPlayerOrder(session, rand.Int63()),
+	}
+
+	if err := s.sessionManager.AddPlayerToQuizSession(ctx, session.ID, sessionPlayer, 1); err != nil {
+		return nil, fmt.Errorf("failed to add player to session: %w", err)
+	}
+
+	if err := s.scheduleSessionTimeout(ctx, session.ID); err != nil {
+		return nil, err
+	}
+
+	shown := session.Questions
+	if session.Adaptive {
+		shown = shown[session.CurrentQuestion : session.CurrentQuestion+1]
+	}
+
+	return &JoinQuizResponse{
+		QuizID:    quiz.ID,
+		SessionID: session.ID,
+		PlayerID:  sessionPlayer.ID,
+		Practice:  true,
+		Adaptive:  session.Adaptive,
+
+		Phase: session.Phase,
+
+		TimeLimit: questionTimeLimit(quiz, models.Question{}),
+		Questions: playerQuestions(shown, sessionPlayer.Order),
+	}, nil
+}
+
+// PracticeResults returns the saved practice results of a quiz, only those
+// of username when it is set
+func (s *QuizService) PracticeResults(ctx context.Context, quizID, username string) ([]*models.QuizResult, error) {
+	results, err := s.quizReader.GetPracticeResults(ctx, quizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get practice results: %w", err)
+	}
+
+	if username == "" {
+		return results, nil
+	}
+
+	mine := make([]*models.QuizResult, 0, len(results))
+	for _, result := range results {
+		if strings.EqualFold(result.Username, username) {
+			mine = append(mine, result)
+		}
+	}
+
+	return mine, nil
+}
+
+// explainQuestion returns the explanation written for the question. Without
+// one it falls back to the word bank entry the question asks about, and then
+// to the meaning for the types where it describes the word.
+func (s *QuizService) explainQuestion(ctx context.Context, question models.Question) string {
+	if question.Explanation != "" {
+		return question.Explanation
+	}
+
+	if question.WordID != "" {
+		word, err := s.wordBank.FindWord(ctx, question.WordID)
+		if err == nil && word != nil && len(word.Definitions) > 0 {
+			explanation := word.Lemma
+			if word.PartOfSpeech != "" {
+				explanation += " (" + word.PartOfSpeech + ")"
+			}
+			explanation += ": " + word.Definitions[0]
+			if len(word.Examples) > 0 {
+				explanation += ". " + word.Examples[0]
+			}
+			return explanation
+		}
+	}
+
+	switch question.Kind() {
+	case models.QuestionMultipleChoice, models.QuestionSpelling:
+		if question.Meaning != "" {
+			return question.Word + ": " + question.Meaning
+		}
+	}
+
+	return ""
+}
@@ -3,6 +3,7 @@ package inmemory
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -12,10 +13,10 @@ import (
 )
 
 type QuizRepository struct {
-	mu        sync.RWMutex
-	quizzes   map[string]*models.Quiz
-	questions map[string][]models.Question
-	results   map[string][]*models.QuizResult
+	mu       sync.RWMutex
+	versions map[string][]models.QuizVersion // quizID -> versions, oldest first
+	results  map[string][]*models.QuizResult
+	practice map[string][]*models.QuizResult
 }
 
 var _ quizrepositories.QuizReader = (*QuizRepository)(nil)
@@ -23,9 +24,9 @@ var _ quizrepositories.QuizWriter = (*QuizRepository)(nil)
 
 func NewQuizRepository() *QuizRepository {
 	repo := &QuizRepository{
-		quizzes:   make(map[string]*models.Quiz),
-		questions: make(map[string][]models.Question),
-		results:   make(map[string][]*models.QuizResult),
+		versions: make(map[string][]models.QuizVersion),
+		results:  make(map[string][]*models.QuizResult),
+		practice: make(map[string][]*models.QuizResult),
 	}
 
 	// Initialize with sample data
@@ -33,27 +34,34 @@ func NewQuizRepository() *QuizRepository {
 	return repo
 }
 
-func (r *QuizRepository) CreateQuiz(ctx context.Context, quiz *models.Quiz) error {
+func (r *QuizRepository) CreateQuiz(ctx context.Context, quiz *models.Quiz, questions []models.Question) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.quizzes[quiz.ID]; exists {
-		return fmt.Errorf("quiz already exists: %s", quiz.ID)
+	if _, exists := r.versions[quiz.ID]; exists {
+		return fmt.Errorf("%w: %s", quizrepositories.ErrQuizExists, quiz.ID)
 	}
 
-	r.quizzes[quiz.ID] = quiz
+	quiz.Status = models.QuizStatusDraft
+	r.appendVersion(quiz, questions)
 	return nil
 }
 
-func (r *QuizRepository) UpdateQuiz(ctx context.Context, quiz *models.Quiz) error {
+func (r *QuizRepository) UpdateQuiz(ctx context.Context, quiz *models.Quiz, questions []models.Question) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.quizzes[quiz.ID]; !exists {
-		return fmt.Errorf("quiz not found: %s", quiz.ID)
+	latest, err := r.latest(quiz.ID)
+	if err != nil {
+		return err
 	}
 
-	r.quizzes[quiz.ID] = quiz
+	if questions == nil {
+		questions = latest.Questions
+	}
+
+	quiz.Status = models.QuizStatusDraft
+	r.appendVersion(quiz, questions)
 	return nil
 }
 
@@ -61,11 +69,65 @@ func (r *QuizRepository) MapQuestions(ctx context.Context, quizID string, questi
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.quizzes[quizID]; !exists {
-		return fmt.Errorf("quiz not found: %s", quizID)
+	latest, err := r.latest(quizID)
+	if err != nil {
+		return err
+	}
+
+	quiz := latest.Quiz
+	quiz.Status = models.QuizStatusDraft
+	r.appendVersion(&quiz, questions)
+	return nil
+}
+
+func (r *QuizRepository) RollbackQuiz(ctx context.Context, quizID string, version int) (*models.QuizVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	target, err := r.version(quizID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz := target.Quiz
+	quiz.Status = models.QuizStatusDraft
+	restored := r.appendVersion(&quiz, target.Questions)
+	return &restored, nil
+}
+
+func (r *QuizRepository) ChangeQuizStatus(ctx context.Context, quizID string, version int, change models.QuizStatusChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.version(quizID, version); err != nil {
+		return err
+	}
+
+	versions := r.versions[quizID]
+	target := &versions[version-1]
+	if target.Quiz.Status != change.From {
+		return fmt.Errorf("%w: %s v%d is %s", quizrepositories.ErrStatusConflict, quizID, version, target.Quiz.Status)
+	}
+
+	// Only one version is played at a time
+	if change.To == models.QuizStatusPublished {
+		for i := range versions {
+			if versions[i].Quiz.Status != models.QuizStatusPublished {
+				continue
+			}
+			versions[i].Quiz.Status = models.QuizStatusArchived
+			versions[i].StatusHistory = append(versions[i].StatusHistory, models.QuizStatusChange{
+				From:    models.QuizStatusPublished,
+				To:      models.QuizStatusArchived,
+				Actor:   change.Actor,
+				Comment: fmt.Sprintf("superseded by version %d", version),
+				At:      change.At,
+			})
+		}
 	}
 
-	r.questions[quizID] = questions
+	target.Quiz.Status = change.To
+	target.StatusHistory = append(target.StatusHistory, change)
 	return nil
 }
 
@@ -73,23 +135,220 @@ func (r *QuizRepository) GetQuiz(ctx context.Context, id string) (*models.Quiz,
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	quiz, exists := r.quizzes[id]
+	latest, err := r.latest(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := cloneVersion(latest)
+	return &v.Quiz, v.Questions, nil
+}
+
+func (r *QuizRepository) ListQuizzes(ctx context.Context) ([]models.QuizVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	quizzes := make([]models.QuizVersion, 0, len(r.versions))
+	for id := range r.versions {
+		latest, err := r.latest(id)
+		if err != nil {
+			return nil, err
+		}
+		quizzes = append(quizzes, cloneVersion(latest))
+	}
+
+	sort.Slice(quizzes, func(i, j int) bool {
+		return quizzes[i].Quiz.ID < quizzes[j].Quiz.ID
+	})
+
+	return quizzes, nil
+}
+
+func (r *QuizRepository) GetPublishedQuiz(ctx context.Context, id string) (*models.Quiz, []models.Question, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions, exists := r.versions[id]
 	if !exists {
-		return nil, nil, fmt.Errorf("quiz not found: %s", id)
+		return nil, nil, fmt.Errorf("%w: %s", quizrepositories.ErrQuizNotFound, id)
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].Quiz.Status == models.QuizStatusPublished {
+			v := cloneVersion(versions[i])
+			return &v.Quiz, v.Questions, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("%w: %s", quizrepositories.ErrNotPublished, id)
+}
+
+func (r *QuizRepository) GetQuizVersion(ctx context.Context, id string, version int) (*models.QuizVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	v, err := r.version(id, version)
+	if err != nil {
+		return nil, err
 	}
 
-	questions := r.questions[id]
-	return quiz, questions, nil
+	clone := cloneVersion(v)
+	return &clone, nil
+}
+
+func (r *QuizRepository) ListQuizVersions(ctx context.Context, id string) ([]models.QuizVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions, exists := r.versions[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", quizrepositories.ErrQuizNotFound, id)
+	}
+
+	list := make([]models.QuizVersion, 0, len(versions))
+	for _, v := range versions {
+		list = append(list, cloneVersion(v))
+	}
+	return list, nil
 }
 
 func (r *QuizRepository) SaveQuizResult(ctx context.Context, result *models.QuizResult) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.results[result.QuizID] = append(r.results[result.QuizID], result)
+	r.results[result.QuizID] = upsertResult(r.results[result.QuizID], result)
+	return nil
+}
+
+func (r *QuizRepository) GetQuizResults(ctx context.Context, quizID string) ([]*models.QuizResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*models.QuizResult, len(r.results[quizID]))
+	copy(results, r.results[quizID])
+	return results, nil
+}
+
+func (r *QuizRepository) SavePracticeResult(ctx context.Context, result *models.QuizResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.practice[result.QuizID] = upsertResult(r.practice[result.QuizID], result)
 	return nil
 }
 
+// upsertResult replaces the result with the same ID or appends a new one
+func upsertResult(results []*models.QuizResult, result *models.QuizResult) []*models.QuizResult {
+	for i, saved := range results {
+		if saved.ID == result.ID {
+			results[i] = result
+			return results
+		}
+	}
+	return append(results, result)
+}
+
+func (r *QuizRepository) GetPracticeResults(ctx context.Context, quizID string) ([]*models.QuizResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*models.QuizResult, len(r.practice[quizID]))
+	copy(results, r.practice[quizID])
+	return results, nil
+}
+
+func (r *QuizRepository) GetPlayerResults(ctx context.Context, playerID string) ([]*models.QuizResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return playerResults(r.results, playerID), nil
+}
+
+func (r *QuizRepository) GetPlayerPracticeResults(ctx context.Context, playerID string) ([]*models.QuizResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return playerResults(r.practice, playerID), nil
+}
+
+// playerResults collects the results of one player from every quiz, oldest
+// first. Callers must hold the lock.
+func playerResults(byQuiz map[string][]*models.QuizResult, playerID string) []*models.QuizResult {
+	results := []*models.QuizResult{}
+	for _, quizResults := range byQuiz {
+		for _, result := range quizResults {
+			if result.PlayerID == playerID {
+				results = append(results, result)
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].CompletionTime.Before(results[j].CompletionTime)
+	})
+
+	return results
+}
+
+// appendVersion stores a copy of the quiz and questions as the next version
+// and reports the version number back through quiz.Version. Callers must
+// hold the write lock.
+func (r *QuizRepository) appendVersion(quiz *models.Quiz, questions []models.Question) models.QuizVersion {
+	quiz.Version = len(r.versions[quiz.ID]) + 1
+
+	v := cloneVersion(models.QuizVersion{
+		Quiz:      *quiz,
+		Questions: questions,
+		CreatedAt: time.Now(),
+	})
+	r.versions[quiz.ID] = append(r.versions[quiz.ID], v)
+
+	return cloneVersion(v)
+}
+
+func (r *QuizRepository) latest(id string) (models.QuizVersion, error) {
+	versions, exists := r.versions[id]
+	if !exists || len(versions) == 0 {
+		return models.QuizVersion{}, fmt.Errorf("%w: %s", quizrepositories.ErrQuizNotFound, id)
+	}
+	return versions[len(versions)-1], nil
+}
+
+func (r *QuizRepository) version(id string, version int) (models.QuizVersion, error) {
+	versions, exists := r.versions[id]
+	if !exists {
+		return models.QuizVersion{}, fmt.Errorf("%w: %s", quizrepositories.ErrQuizNotFound, id)
+	}
+	if version < 1 || version > len(versions) {
+		return models.QuizVersion{}, fmt.Errorf("%w: %s v%d", quizrepositories.ErrVersionNotFound, id, version)
+	}
+	return versions[version-1], nil
+}
+
+// cloneVersion deep copies a version so stored history can't be changed
+// through the returned value
+func cloneVersion(v models.QuizVersion) models.QuizVersion {
+	questions := make([]models.Question, len(v.Questions))
+	for i, q := range v.Questions {
+		q.Options = append([]string(nil), q.Options...)
+		q.Pairs = append([]models.MatchPair(nil), q.Pairs...)
+		q.Tags = append([]string(nil), q.Tags...)
+		questions[i] = q
+	}
+	v.Questions = questions
+	if v.Quiz.Scoring != nil {
+		scoring := *v.Quiz.Scoring
+		v.Quiz.Scoring = &scoring
+	}
+	if v.Quiz.Pool != nil {
+		pool := *v.Quiz.Pool
+		pool.Rules = append([]models.PoolRule(nil), pool.Rules...)
+		v.Quiz.Pool = &pool
+	}
+	v.StatusHistory = append([]models.QuizStatusChange(nil), v.StatusHistory...)
+	return v
+}
+
 // Sample data initialization
 func (r *QuizRepository) initSampleData() {
 	sampleQuizzes := []struct {
@@ -100,7 +359,7 @@ func (r *QuizRepository) initSampleData() {
 			quiz: &models.Quiz{
 				ID:        "quiz1",
 				Title:     "Basic Animals",
-				Status:    models.QuizStatusActive,
+				Status:    models.QuizStatusPublished,
 				CreatedAt: time.Now(),
 			},
 			questions: []models.Question{
@@ -126,7 +385,7 @@ func (r *QuizRepository) initSampleData() {
 			quiz: &models.Quiz{
 				ID:        "quiz2",
 				Title:     "Marine Life",
-				Status:    models.QuizStatusActive,
+				Status:    models.QuizStatusPublished,
 				CreatedAt: time.Now(),
 			},
 			questions: []models.Question{
@@ -152,7 +411,7 @@ func (r *QuizRepository) initSampleData() {
 			quiz: &models.Quiz{
 				ID:        "quiz3",
 				Title:     "Birds of Prey",
-				Status:    models.QuizStatusActive,
+				Status:    models.QuizStatusPublished,
 				CreatedAt: time.Now(),
 			},
 			questions: []models.Question{
@@ -178,7 +437,7 @@ func (r *QuizRepository) initSampleData() {
 			quiz: &models.Quiz{
 				ID:        "quiz4",
 				Title:     "Nocturnal Animals",
-				Status:    models.QuizStatusActive,
+				Status:    models.QuizStatusPublished,
 				CreatedAt: time.Now(),
 			},
 			questions: []models.Question{
@@ -204,7 +463,7 @@ func (r *QuizRepository) initSampleData() {
 			quiz: &models.Quiz{
 				ID:        "quiz5",
 				Title:     "Unusual Mammals",
-				Status:    models.QuizStatusActive,
+				Status:    models.QuizStatusPublished,
 				CreatedAt: time.Now(),
 			},
 			questions: []models.Question{
@@ -226,11 +485,78 @@ func (r *QuizRepository) initSampleData() {
 				},
 			},
 		},
+		{
+			quiz: &models.Quiz{
+				ID:        "quiz6",
+				Title:     "Word Play",
+				Status:    models.QuizStatusPublished,
+				CreatedAt: time.Now(),
+			},
+			questions: []models.Question{
+				{
+					ID:      "q6_1",
+					QuizID:  "quiz6",
+					WordID:  "w_nocturnal",
+					Type:    models.QuestionTrueFalse,
+					Word:    "Nocturnal",
+					Meaning: "Active during the day",
+					Correct: "false",
+
+					Explanation: "Nocturnal animals are active at night, diurnal ones during the day",
+				},
+				{
+					ID:       "q6_2",
+					QuizID:   "quiz6",
+					WordID:   "w_hibernate",
+					Type:     models.QuestionFillBlank,
+					Word:     "Hibernate",
+					Sentence: "Bears ___ through the winter in their dens",
+				},
+				{
+					ID:      "q6_3",
+					QuizID:  "quiz6",
+					WordID:  "w_camouflage",
+					Type:    models.QuestionSpelling,
+					Word:    "Camouflage",
+					Meaning: "Colouring that lets an animal blend into its surroundings",
+				},
+				{
+					ID:      "q6_4",
+					QuizID:  "quiz6",
+					WordID:  "w_ferocious",
+					Type:    models.QuestionSynonym,
+					Word:    "Ferocious",
+					Options: []string{"Savage", "Gentle", "Timid", "Sleepy"},
+					Correct: "Savage",
+				},
+				{
+					ID:      "q6_5",
+					QuizID:  "quiz6",
+					WordID:  "w_predator",
+					Type:    models.QuestionAntonym,
+					Word:    "Predator",
+					Options: []string{"Prey", "Hunter", "Carnivore", "Stalker"},
+					Correct: "Prey",
+				},
+				{
+					ID:     "q6_6",
+					QuizID: "quiz6",
+					Type:   models.QuestionMatchPairs,
+					Word:   "Baby animals",
+					Pairs: []models.MatchPair{
+						{Word: "Kangaroo", Meaning: "Joey"},
+						{Word: "Swan", Meaning: "Cygnet"},
+						{Word: "Hare", Meaning: "Leveret"},
+					},
+
+					Explanation: "A young kangaroo is a joey, a young swan a cygnet and a young hare a leveret",
+				},
+			},
+		},
 	}
 
 	// Store sample data
 	for _, sample := range sampleQuizzes {
-		r.quizzes[sample.quiz.ID] = sample.quiz
-		r.questions[sample.quiz.ID] = sample.questions
+		r.appendVersion(sample.quiz, sample.questions)
 	}
 }
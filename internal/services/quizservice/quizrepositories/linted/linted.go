@@ -0,0 +1,92 @@
+package linted
+
+import (
+	"context"
+	"fmt"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/pkg/quizlint"
+	"wordwizardry/internal/services/quizservice/quizrepositories"
+)
+
+// QuizWriter runs the quizlint rules before every content write and refuses
+// quizzes with error level issues. Everything else is passed through.
+type QuizWriter struct {
+	next   quizrepositories.QuizWriter
+	reader quizrepositories.QuizReader
+}
+
+var _ quizrepositories.QuizWriter = (*QuizWriter)(nil)
+
+// NewQuizWriter wraps next. The reader provides the current quiz or questions
+// when a write only carries one of them.
+func NewQuizWriter(next quizrepositories.QuizWriter, reader quizrepositories.QuizReader) *QuizWriter {
+	return &QuizWriter{
+		next:   next,
+		reader: reader,
+	}
+}
+
+func (w *QuizWriter) CreateQuiz(ctx context.Context, quiz *models.Quiz, questions []models.Question) error {
+	if err := quizlint.Validate(quiz, questions); err != nil {
+		return err
+	}
+
+	return w.next.CreateQuiz(ctx, quiz, questions)
+}
+
+func (w *QuizWriter) UpdateQuiz(ctx context.Context, quiz *models.Quiz, questions []models.Question) error {
+	checked := questions
+	if checked == nil {
+		_, current, err := w.reader.GetQuiz(ctx, quiz.ID)
+		if err != nil {
+			return err
+		}
+		checked = current
+	}
+
+	if err := quizlint.Validate(quiz, checked); err != nil {
+		return err
+	}
+
+	return w.next.UpdateQuiz(ctx, quiz, questions)
+}
+
+func (w *QuizWriter) MapQuestions(ctx context.Context, quizID string, questions []models.Question) error {
+	quiz, _, err := w.reader.GetQuiz(ctx, quizID)
+	if err != nil {
+		return err
+	}
+
+	if err := quizlint.Validate(quiz, questions); err != nil {
+		return err
+	}
+
+	return w.next.MapQuestions(ctx, quizID, questions)
+}
+
+// RollbackQuiz restores content that was written before, possibly under
+// older rules, so it is left to the review workflow to catch
+func (w *QuizWriter) RollbackQuiz(ctx context.Context, quizID string, version int) (*models.QuizVersion, error) {
+	return w.next.RollbackQuiz(ctx, quizID, version)
+}
+
+func (w *QuizWriter) ChangeQuizStatus(ctx context.Context, quizID string, version int, change models.QuizStatusChange) error {
+	return w.next.ChangeQuizStatus(ctx, quizID, version, change)
+}
+
+func (w *QuizWriter) SaveQuizResult(ctx context.Context, quizResult *models.QuizResult) error {
+	if quizResult == nil {
+		return fmt.Errorf("quiz result is required")
+	}
+
+	return w.next.SaveQuizResult(ctx, quizResult)
+}
+
+func (w *QuizWriter) SavePracticeResult(ctx context.Context, quizResult *models.QuizResult) error {
+	if quizResult == nil {
+		return fmt.Errorf("quiz result is required")
+	}
+
+	return w.next.SavePracticeResult(ctx, quizResult)
+}
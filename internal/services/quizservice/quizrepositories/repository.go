@@ -2,17 +2,53 @@ package quizrepositories
 
 import (
 	"context"
+	"errors"
 
 	"wordwizardry/internal/pkg/models"
 )
 
+var (
+	ErrQuizNotFound    = errors.New("quiz not found")
+	ErrQuizExists      = errors.New("quiz already exists")
+	ErrVersionNotFound = errors.New("quiz version not found")
+	ErrNotPublished    = errors.New("quiz has no published version")
+	ErrStatusConflict  = errors.New("quiz status changed concurrently")
+)
+
+// QuizWriter never modifies the content of a stored quiz, every write appends
+// a new draft version. Only the status of a version changes afterwards.
 type QuizWriter interface {
-	CreateQuiz(ctx context.Context, quiz *models.Quiz) error
-	UpdateQuiz(ctx context.Context, quiz *models.Quiz) error
+	// creates version 1
+	CreateQuiz(ctx context.Context, quiz *models.Quiz, questions []models.Question) error
+	// new version with the given metadata, nil questions keeps the current ones
+	UpdateQuiz(ctx context.Context, quiz *models.Quiz, questions []models.Question) error
+	// new version with the current metadata and the given questions
 	MapQuestions(ctx context.Context, quizID string, questions []models.Question) error
+	// new version with the content of an older one
+	RollbackQuiz(ctx context.Context, quizID string, version int) (*models.QuizVersion, error)
+	// moves a version from change.From to change.To, publishing a version
+	// archives the previously published one
+	ChangeQuizStatus(ctx context.Context, quizID string, version int, change models.QuizStatusChange) error
+	// a result with the ID of a saved one replaces it, so the results of a
+	// session can be saved again
 	SaveQuizResult(ctx context.Context, quizResult *models.QuizResult) error
+	// practice results are stored apart and never ranked with the others
+	SavePracticeResult(ctx context.Context, quizResult *models.QuizResult) error
 }
 
 type QuizReader interface {
+	// latest version
 	GetQuiz(ctx context.Context, id string) (*models.Quiz, []models.Question, error)
+	GetQuizVersion(ctx context.Context, id string, version int) (*models.QuizVersion, error)
+	// newest published version, the one players get
+	GetPublishedQuiz(ctx context.Context, id string) (*models.Quiz, []models.Question, error)
+	// oldest first
+	ListQuizVersions(ctx context.Context, id string) ([]models.QuizVersion, error)
+	// latest version of every quiz, by quiz ID
+	ListQuizzes(ctx context.Context) ([]models.QuizVersion, error)
+	GetQuizResults(ctx context.Context, quizID string) ([]*models.QuizResult, error)
+	GetPracticeResults(ctx context.Context, quizID string) ([]*models.QuizResult, error)
+	// results of one player across quizzes, oldest first
+	GetPlayerResults(ctx context.Context, playerID string) ([]*models.QuizResult, error)
+	GetPlayerPracticeResults(ctx context.Context, playerID string) ([]*models.QuizResult, error)
 }
@@ -2,14 +2,25 @@ package quizservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
-
-	"github.com/google/uuid"
+	"log"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
 
 	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/pkg/questionpool"
 
 	"wordwizardry/internal/services/broadcast"
+	"wordwizardry/internal/services/quizservice/players"
+	"wordwizardry/internal/services/quizservice/ratings"
+	"wordwizardry/internal/services/quizservice/reviews"
+	"wordwizardry/internal/services/quizservice/schedules"
+	"wordwizardry/internal/services/quizservice/scoring"
 	"wordwizardry/internal/services/quizservice/sessions"
+	"wordwizardry/internal/services/quizservice/wordbank"
 
 	"wordwizardry/internal/services/quizservice/quizrepositories"
 )
@@ -19,7 +30,22 @@ type QuizService struct {
 	quizWriter quizrepositories.QuizWriter
 
 	sessionManager sessions.SessionManager
+	scheduleStore  schedules.ScheduleStore
+	wordBank       wordbank.WordBank
+	reviewStore    reviews.ReviewStore
+	ratingStore    ratings.RatingStore
+	playerRepo     players.PlayerRepository
 	hub            broadcast.Hub
+
+	// accounts that write, review and publish quizzes
+	editors map[string]bool
+
+	// serializes schedule changes between the API and the scheduler
+	scheduleMu sync.Mutex
+	// serializes moving hosted sessions to their next question
+	advanceMu sync.Mutex
+	// serializes finding or creating the open session of a quiz
+	openMu sync.Mutex
 }
 
 func NewQuizService(
@@ -27,6 +53,11 @@ func NewQuizService(
 	quizWriter quizrepositories.QuizWriter,
 
 	sessionManager sessions.SessionManager,
+	scheduleStore schedules.ScheduleStore,
+	wordBank wordbank.WordBank,
+	reviewStore reviews.ReviewStore,
+	ratingStore ratings.RatingStore,
+	playerRepo players.PlayerRepository,
 	hub broadcast.Hub,
 ) *QuizService {
 	return &QuizService{
@@ -34,88 +65,376 @@ func NewQuizService(
 		quizWriter: quizWriter,
 
 		sessionManager: sessionManager,
+		scheduleStore:  scheduleStore,
+		wordBank:       wordBank,
+		reviewStore:    reviewStore,
+		ratingStore:    ratingStore,
+		playerRepo:     playerRepo,
 		hub:            hub,
 	}
 }
 
+// SetEditors replaces the accounts allowed to write, review and publish
+// quizzes. Nobody can until it is called.
+func (s *QuizService) SetEditors(accountIDs []string) {
+	editors := make(map[string]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		editors[id] = true
+	}
+	s.editors = editors
+}
+
+// JoinQuiz adds a player to a session. With a session ID the player joins
+// that session. With only a quiz ID the player joins the scheduled live
+// session, or the quiz's open session when the quiz is not live.
+// Signed in players join as their account and rejoin a session they are
+// already in. Hosted sessions are only joined by their code, see JoinByCode.
+// Players the host kicked cannot join the session again.
 func (s *QuizService) JoinQuiz(ctx context.Context, req JoinQuizRequest) (*JoinQuizResponse, error) {
-	quiz, questions, err := s.quizReader.GetQuiz(ctx, req.QuizID)
+	return s.joinQuiz(ctx, req, false)
+}
+
+// joinQuiz adds a player as JoinQuiz does, byCode is set when the session
+// was found by its join code
+func (s *QuizService) joinQuiz(ctx context.Context, req JoinQuizRequest, byCode bool) (*JoinQuizResponse, error) {
+	player, err := s.joiningPlayer(ctx, req.Token, req.Username)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get quiz: %w", err)
+		return nil, err
 	}
 
-	if quiz == nil || quiz.Status != models.QuizStatusActive || len(questions) == 0 {
-		return nil, fmt.Errorf("quiz not found")
+	session, err := s.joinableSession(ctx, req, byCode)
+	if err != nil {
+		return nil, err
 	}
 
-	session, err := s.sessionManager.FindQuizSessionByQuizID(ctx, req.QuizID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find session: %w", err)
+	if slices.Contains(session.Kicked, player.ID) {
+		return nil, ErrKicked
 	}
 
-	if session == nil {
-		session = &models.Session{
-			Quiz:      quiz,
-			Questions: questions,
-			Players:   []models.SessionPlayer{},
-			Result:    make(map[string]models.Answer),
+	sessionPlayer := findSessionPlayer(session, player.ID)
+	if sessionPlayer == nil {
+		if session.Phase != models.SessionPhaseLobby && session.Settings.LateJoin == models.LateJoinDeny {
+			return nil, ErrSessionStarted
 		}
 
-		err = s.sessionManager.CreateQuizSession(ctx, session)
+		sessionPlayer = &models.SessionPlayer{
+			Player: player,
+			QuizID: session.Quiz.ID,
+			Score:  0,
+			Order:  newPlayerOrder(session, rand.Int63()),
+		}
+
+		err = s.sessionManager.AddPlayerToQuizSession(ctx, session.ID, *sessionPlayer, session.Settings.MaxPlayers)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create session: %w", err)
+			return nil, fmt.Errorf("failed to add player to session: %w", err)
 		}
 
-		if err := s.hub.CreateRoom(session.ID); err != nil {
-			return nil, fmt.Errorf("failed to create room: %w", err)
+		if session.Phase == models.SessionPhaseLobby {
+			if err := s.scheduleLobbyTimeout(ctx, session); err != nil {
+				log.Printf("failed to schedule lobby timeout: %v", err)
+			}
 		}
 	}
 
-	player := models.Player{
-		ID:       uuid.New().String(),
-		Username: req.Username,
+	err = s.hub.JoinRoom(session.ID, player.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join room: %w", err)
 	}
 
-	sessionPlayer := models.SessionPlayer{
-		Player: player,
-		QuizID: req.Username,
-		Score:  0,
+	return &JoinQuizResponse{
+		QuizID:    session.Quiz.ID,
+		SessionID: session.ID,
+		JoinCode:  session.JoinCode,
+		PlayerID:  player.ID,
+
+		Hosted:          session.Hosted(),
+		Phase:           session.Phase,
+		CurrentQuestion: session.CurrentQuestion,
+
+		TimeLimit: questionTimeLimit(session.Quiz, models.Question{}),
+		Questions: playerQuestions(session.Questions, sessionPlayer.Order),
+	}, nil
+}
+
+// CreateSession opens a hosted session of the published version of a quiz.
+// Every call gets its own session, so groups playing the same quiz at the
+// same time do not share a room. The session waits in the lobby until the
+// host starts it, the host key is only returned here.
+func (s *QuizService) CreateSession(ctx context.Context, req CreateSessionRequest) (*CreateSessionResponse, error) {
+	if err := validateSessionSettings(req.Settings); err != nil {
+		return nil, err
 	}
 
-	err = s.sessionManager.AddPlayerToQuizSession(ctx, session.ID, sessionPlayer)
+	quiz, questions, err := s.openQuiz(ctx, req.QuizID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add player to session: %w", err)
+		return nil, err
 	}
 
-	err = s.hub.JoinRoom(session.ID, player.ID)
+	hostKey, hostKeyHash, err := newSecret()
 	if err != nil {
-		return nil, fmt.Errorf("failed to join room: %w", err)
+		return nil, err
 	}
 
-	return &JoinQuizResponse{
-		SessionID: session.ID,
-		PlayerID:  player.ID,
-		Questions: questions,
+	session, err := s.createSession(ctx, quiz, questions, &sessionHost{
+		name:     req.HostName,
+		keyHash:  hostKeyHash,
+		settings: req.Settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateSessionResponse{
+		SessionSummary: *summarizeSession(session),
+		JoinCode:       session.JoinCode,
+		HostKey:        hostKey,
 	}, nil
 }
 
+// ActiveSessions lists the sessions of a quiz that have not finished
+func (s *QuizService) ActiveSessions(ctx context.Context, quizID string) ([]*SessionSummary, error) {
+	active, err := s.sessionManager.FindActiveQuizSessions(ctx, quizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+
+	summaries := make([]*SessionSummary, 0, len(active))
+	for _, session := range active {
+		summaries = append(summaries, summarizeSession(session))
+	}
+
+	return summaries, nil
+}
+
+func (s *QuizService) joinableSession(ctx context.Context, req JoinQuizRequest, byCode bool) (*models.Session, error) {
+	if req.SessionID != "" {
+		session, err := s.sessionManager.FindQuizSession(ctx, req.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find session: %w", err)
+		}
+
+		// Practice sessions are private to the player who started them
+		if session == nil || session.Practice || (req.QuizID != "" && session.Quiz.ID != req.QuizID) {
+			return nil, ErrSessionNotFound
+		}
+
+		if session.Phase == models.SessionPhaseFinished {
+			return nil, ErrSessionFinished
+		}
+
+		// Session IDs are shared with everyone in the room, the join code
+		// is what the host hands out and its guesses are limited
+		if session.Hosted() && !byCode {
+			return nil, ErrJoinByCode
+		}
+
+		return session, nil
+	}
+
+	quiz, questions, err := s.openQuiz(ctx, req.QuizID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.scheduleStore.FindSchedule(ctx, req.QuizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find schedule: %w", err)
+	}
+
+	// A scheduled live quiz is played in the session the scheduler creates,
+	// players cannot start one early
+	if schedule != nil && schedule.StartsAt != nil {
+		if schedule.SessionID == "" {
+			return nil, ErrQuizNotStarted
+		}
+
+		return s.joinableSession(ctx, JoinQuizRequest{QuizID: req.QuizID, SessionID: schedule.SessionID}, false)
+	}
+
+	return s.openSession(ctx, quiz, questions)
+}
+
+// openSession returns the session everyone joining the quiz by its ID
+// plays in: the newest unhosted session of the published version that has
+// not finished, or a new one when there is none. Groups that want a room of
+// their own create a hosted session and join it by its code.
+func (s *QuizService) openSession(ctx context.Context, quiz *models.Quiz, questions []models.Question) (*models.Session, error) {
+	s.openMu.Lock()
+	defer s.openMu.Unlock()
+
+	active, err := s.sessionManager.FindActiveQuizSessions(ctx, quiz.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+
+	for i := len(active) - 1; i >= 0; i-- {
+		session := active[i]
+		if !session.Hosted() && session.Quiz.Version == quiz.Version &&
+			session.Phase != models.SessionPhaseFinished {
+			return session, nil
+		}
+	}
+
+	return s.createSession(ctx, quiz, questions, nil)
+}
+
+// openQuiz returns the published version of a quiz when its schedule, if it
+// has one, lets new sessions start
+func (s *QuizService) openQuiz(ctx context.Context, quizID string) (*models.Quiz, []models.Question, error) {
+	// Players only ever get the published version, drafts stay with editors
+	quiz, questions, err := s.quizReader.GetPublishedQuiz(ctx, quizID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get quiz: %w", err)
+	}
+
+	if quiz == nil || len(questions) == 0 {
+		return nil, nil, ErrQuizNotFound
+	}
+
+	schedule, err := s.scheduleStore.FindSchedule(ctx, quizID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find schedule: %w", err)
+	}
+
+	if schedule != nil && schedule.State != models.QuizScheduleStateOpen {
+		return nil, nil, ErrQuizNotOpen
+	}
+
+	return quiz, questions, nil
+}
+
+func summarizeSession(session *models.Session) *SessionSummary {
+	return &SessionSummary{
+		ID:          session.ID,
+		QuizID:      session.Quiz.ID,
+		QuizVersion: session.Quiz.Version,
+		Phase:       session.Phase,
+		Players:     len(session.Players),
+		CreatedAt:   session.CreatedAt,
+	}
+}
+
+type sessionHost struct {
+	name     string
+	keyHash  string
+	settings models.SessionSettings
+}
+
+// createSession starts a session of the quiz with its room. Sessions with a
+// host wait in the lobby, the others start right away. Quizzes with a pool
+// get their questions drawn here.
+func (s *QuizService) createSession(ctx context.Context, quiz *models.Quiz, questions []models.Question, host *sessionHost) (*models.Session, error) {
+	questions, drawSeed, err := s.drawQuestions(ctx, quiz, questions)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &models.Session{
+		Quiz:      quiz,
+		Phase:     models.SessionPhaseInProgress,
+		Questions: questions,
+		Players:   []models.SessionPlayer{},
+		Result:    make(map[string]models.Answer),
+		DrawSeed:  drawSeed,
+	}
+
+	session.Weights, err = s.difficultyWeights(ctx, quiz, questions)
+	if err != nil {
+		return nil, err
+	}
+
+	if host != nil {
+		session.Phase = models.SessionPhaseLobby
+		session.HostName = host.name
+		session.HostKeyHash = host.keyHash
+		session.Settings = host.settings
+	}
+
+	if err := s.sessionManager.CreateQuizSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if err := s.hub.CreateRoom(session.ID); err != nil {
+		return nil, fmt.Errorf("failed to create room: %w", err)
+	}
+
+	if session.Hosted() {
+		if err := s.hub.JoinRoom(session.ID, HostClientID); err != nil {
+			return nil, fmt.Errorf("failed to join room: %w", err)
+		}
+	}
+
+	if err := s.scheduleSessionTimeout(ctx, session.ID); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// drawQuestions picks the questions of a session from the quiz's pool, and
+// returns them all when it has none. Questions without an authored
+// difficulty are drawn by the one computed from their rating, unless that
+// makes the pool impossible to draw: the lint only checked the pool against
+// the authored difficulties, the computed ones move with every answer.
+func (s *QuizService) drawQuestions(ctx context.Context, quiz *models.Quiz, questions []models.Question) ([]models.Question, int64, error) {
+	if quiz.Pool == nil {
+		return questions, 0, nil
+	}
+
+	tagged, err := s.tagDifficulty(ctx, quiz.ID, questions)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := questionpool.Check(tagged, quiz.Pool); err == nil {
+		questions = tagged
+	} else {
+		log.Printf("drawing quiz %s by authored difficulty: %v", quiz.ID, err)
+	}
+
+	seed := rand.Int63()
+	drawn, err := questionpool.Draw(questions, quiz.Pool, seed)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return drawn, seed, nil
+}
+
+// SubmitAnswerRequest carries no answer time, answers are timed by the
+// server. Players with an account answer signed in with Token.
 type SubmitAnswerRequest struct {
-	PlayerID            string  `json:"player_id"`
-	SessionID           string  `json:"session_id"`
-	QuizID              string  `json:"quiz_id"`
-	QuestionID          string  `json:"question_id"`
-	Answer              string  `json:"answer"`
-	AnswerTimeInSeconds float64 `json:"answer_time"`
+	PlayerID   string `json:"player_id"`
+	SessionID  string `json:"session_id"`
+	QuizID     string `json:"quiz_id"`
+	QuestionID string `json:"question_id"`
+	Answer     string `json:"answer"`
+	Token      string `json:"-"`
+
+	Matches map[string]string `json:"matches,omitempty"` // match_pairs, word to meaning
 }
 
-func (s *QuizService) SubmitAnswer(ctx context.Context, req SubmitAnswerRequest) error {
+func (s *QuizService) SubmitAnswer(ctx context.Context, req SubmitAnswerRequest) (*AnswerFeedback, error) {
+	if _, err := s.actingPlayer(ctx, req.PlayerID, req.Token); err != nil {
+		return nil, err
+	}
+
 	session, err := s.sessionManager.FindQuizPlayerSession(ctx, req.SessionID, req.PlayerID)
 	if err != nil {
-		return fmt.Errorf("failed to find session: %w", err)
+		return nil, fmt.Errorf("failed to find session: %w", err)
 	}
 
 	if session == nil {
-		return fmt.Errorf("session not found")
+		return nil, ErrSessionNotFound
+	}
+
+	switch session.Phase {
+	case models.SessionPhaseFinished:
+		return nil, ErrSessionFinished
+	case models.SessionPhaseLobby:
+		return nil, ErrSessionNotStarted
+	case models.SessionPhasePaused:
+		return nil, ErrSessionPaused
 	}
 
 	question := models.Question{}
@@ -127,10 +446,16 @@ func (s *QuizService) SubmitAnswer(ctx context.Context, req SubmitAnswerRequest)
 	}
 
 	if question.ID == "" {
-		return fmt.Errorf("question not found")
+		return nil, ErrQuestionNotFound
 	}
 
-	resKey := fmt.Sprintf("%s:%s", req.QuestionID, req.PlayerID)
+	// Hosted sessions move through the questions together, adaptive ones
+	// ask the question picked for the player
+	if (session.Hosted() || session.Adaptive) && session.Questions[session.CurrentQuestion].ID != question.ID {
+		return nil, ErrQuestionNotCurrent
+	}
+
+	resKey := models.ResultKey(req.QuestionID, req.PlayerID)
 
 	hasAnswered := false
 	_, ok := session.Result[resKey]
@@ -139,20 +464,43 @@ func (s *QuizService) SubmitAnswer(ctx context.Context, req SubmitAnswerRequest)
 	}
 
 	if hasAnswered {
-		return fmt.Errorf("question already answered")
+		return nil, ErrAlreadyAnswered
 	}
 
-	correct := false
-	for _, option := range question.Options {
-		if option == req.Answer {
-			correct = true
-			break
-		}
+	limit := questionTimeLimit(session.Quiz, question)
+	answerTime, timed, err := timeAnswer(session, resKey, limit)
+	if err != nil {
+		return nil, err
 	}
 
-	var score int
-	if correct {
-		score = calculateScore(req.AnswerTimeInSeconds)
+	timedOut := answerTime > limit
+
+	eval := evaluateAnswer(question, req)
+	correct := eval.correct && !timedOut
+
+	weight := difficultyWeight(session, question.ID)
+
+	breakdown := scoreAnswer(session, question.ID, req.PlayerID, scoring.Attempt{
+		Correct:    correct,
+		TimedOut:   timedOut,
+		AnswerTime: answerTime,
+		TimeLimit:  limit,
+		Credit:     eval.credit,
+		Weight:     weight,
+	})
+	score := scoring.Total(breakdown)
+
+	answer := models.Answer{
+		PlayerChoice:  eval.choice,
+		CorrectAnswer: question.ExpectedAnswer(),
+		Correct:       correct,
+		Points:        score,
+		AnswerTime:    answerTime,
+		AnsweredAt:    time.Now(),
+		TimedOut:      timedOut,
+		Timed:         timed,
+		Breakdown:     breakdown,
+		Grading:       eval.grading,
 	}
 
 	err = s.sessionManager.UpdateQuizPlayerScoreSession(
@@ -160,19 +508,45 @@ func (s *QuizService) SubmitAnswer(ctx context.Context, req SubmitAnswerRequest)
 		req.SessionID,
 		req.PlayerID,
 		score,
-		models.Result{
-			resKey: models.Answer{
-				PlayerChoice:  req.Answer,
-				CorrectAnswer: question.Correct,
-			},
-		})
+		models.Result{resKey: answer})
+	if errors.Is(err, ErrAlreadyAnswered) {
+		return nil, err
+	}
 	if err != nil {
-		return fmt.Errorf("failed to add score: %w", err)
+		return nil, fmt.Errorf("failed to add score: %w", err)
+	}
+
+	s.recordReview(ctx, req.PlayerID, question, answer, limit)
+	s.recordRating(ctx, session, req.PlayerID, question, answer)
+
+	feedback := &AnswerFeedback{
+		Correct:   correct,
+		Points:    score,
+		TimedOut:  timedOut,
+		Breakdown: breakdown,
+	}
+
+	// Nobody else is playing, the answer and why are shown right away
+	if session.Practice {
+		feedback.CorrectAnswer = question.ExpectedAnswer()
+		feedback.Explanation = s.explainQuestion(ctx, question)
+		feedback.Grading = eval.grading
+
+		if results := s.progressSession(ctx, session.ID); len(results) > 0 {
+			feedback.Result = results[0]
+		} else if session.Adaptive {
+			next, err := s.nextAdaptiveQuestion(ctx, session, req.PlayerID, question.ID)
+			if err != nil {
+				return nil, err
+			}
+			feedback.Next = next
+		}
+		return feedback, nil
 	}
 
 	leaderboard, err := s.sessionManager.FindLeaderboardQuizSession(ctx, session.ID)
 	if err != nil {
-		return fmt.Errorf("failed to get leaderboard: %w", err)
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
 	}
 
 	// Broadcast to room instead of session
@@ -183,6 +557,7 @@ func (s *QuizService) SubmitAnswer(ctx context.Context, req SubmitAnswerRequest)
 				"player_id": req.PlayerID,
 				"correct":   correct,
 				"score":     score,
+				"breakdown": breakdown,
 			},
 		},
 		{
@@ -196,11 +571,13 @@ func (s *QuizService) SubmitAnswer(ctx context.Context, req SubmitAnswerRequest)
 	// Broadcast all messages to the room
 	for _, msg := range messages {
 		if err := s.hub.BroadcastToRoom(ctx, session.ID, msg); err != nil {
-			return fmt.Errorf("failed to broadcast message: %w", err)
+			return nil, fmt.Errorf("failed to broadcast message: %w", err)
 		}
 	}
 
-	return nil
+	s.progressSession(ctx, session.ID)
+
+	return feedback, nil
 }
 
 func (s *QuizService) ValidatePlayerSession(ctx context.Context, sessionID, playerID string) (*models.Session, error) {
@@ -210,7 +587,11 @@ func (s *QuizService) ValidatePlayerSession(ctx context.Context, sessionID, play
 	}
 
 	if session == nil {
-		return nil, fmt.Errorf("session not found")
+		return nil, ErrSessionNotFound
+	}
+
+	if session.Practice {
+		return nil, ErrPracticeSession
 	}
 
 	return session, nil
@@ -0,0 +1,233 @@
+package quizservice
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/pkg/rating"
+)
+
+// rated reports whether answers in the session rate its questions. Only the
+// questions of stored quizzes are, generated review quizzes have no version.
+func rated(session *models.Session) bool {
+	return session.Quiz != nil && session.Quiz.Version > 0
+}
+
+// playerRating returns the rating of a player, DefaultRating for a new one
+func (s *QuizService) playerRating(ctx context.Context, playerID string) (models.Rating, error) {
+	found, err := s.ratingStore.FindPlayerRating(ctx, playerID)
+	if err != nil {
+		return models.Rating{}, fmt.Errorf("failed to find player rating: %w", err)
+	}
+
+	if found == nil {
+		return models.Rating{Value: rating.DefaultRating}, nil
+	}
+
+	return *found, nil
+}
+
+// questionRatings returns the rating of every question by ID. Questions
+// that were never answered start from their authored difficulty.
+func (s *QuizService) questionRatings(ctx context.Context, quizID string, questions []models.Question) (map[string]models.Rating, error) {
+	ids := make([]string, 0, len(questions))
+	for _, q := range questions {
+		ids = append(ids, q.ID)
+	}
+
+	found, err := s.ratingStore.FindQuestionRatings(ctx, quizID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find question ratings: %w", err)
+	}
+
+	for _, q := range questions {
+		if _, ok := found[q.ID]; !ok {
+			found[q.ID] = models.Rating{Value: rating.Initial(q.Difficulty)}
+		}
+	}
+
+	return found, nil
+}
+
+// recordRating moves the ratings of the player and the question after an
+// answer. Questions of sessions that are not rated move the player only.
+// Failures are only logged, they never fail the answer.
+func (s *QuizService) recordRating(ctx context.Context, session *models.Session, playerID string, question models.Question, answer models.Answer) {
+	quizID := ""
+	if rated(session) {
+		quizID = session.Quiz.ID
+	}
+
+	err := s.ratingStore.UpdateRatings(ctx, playerID, quizID, question.ID, func(player, asked *models.Rating) (models.Rating, models.Rating) {
+		updated := models.Rating{Value: rating.DefaultRating}
+		if player != nil {
+			updated = *player
+		}
+
+		updatedAsked := models.Rating{Value: rating.Initial(question.Difficulty)}
+		if asked != nil {
+			updatedAsked = *asked
+		}
+
+		rating.Update(&updated, &updatedAsked, rating.Outcome(answer), answer.AnsweredAt)
+		return updated, updatedAsked
+	})
+	if err != nil {
+		log.Printf("failed to rate answer: %v", err)
+	}
+}
+
+// difficultyWeights weighs the questions of a new competitive session of a
+// quiz scored by difficulty by their ratings, nil for any other session
+func (s *QuizService) difficultyWeights(ctx context.Context, quiz *models.Quiz, questions []models.Question) (map[string]float64, error) {
+	if quiz.Version == 0 || quiz.Scoring == nil || !quiz.Scoring.DifficultyWeighted {
+		return nil, nil
+	}
+
+	ratings, err := s.questionRatings(ctx, quiz.ID, questions)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[string]float64, len(questions))
+	for _, q := range questions {
+		weights[q.ID] = rating.Weight(ratings[q.ID].Value)
+	}
+
+	return weights, nil
+}
+
+// difficultyWeight is the weight of a question's points, the one fixed when
+// the session was created and 1 for sessions without weights
+func difficultyWeight(session *models.Session, questionID string) float64 {
+	if weight, ok := session.Weights[questionID]; ok {
+		return weight
+	}
+	return 1
+}
+
+// tagDifficulty returns the questions with the difficulty their rating puts
+// them at filled in where the author left it out, so pool rules can draw
+// them by difficulty once they have been answered often enough
+func (s *QuizService) tagDifficulty(ctx context.Context, quizID string, questions []models.Question) ([]models.Question, error) {
+	ratings, err := s.questionRatings(ctx, quizID, questions)
+	if err != nil {
+		return nil, err
+	}
+
+	tagged := make([]models.Question, len(questions))
+	copy(tagged, questions)
+	for i := range tagged {
+		if tagged[i].Difficulty == "" {
+			tagged[i].Difficulty = rating.Difficulty(ratings[tagged[i].ID])
+		}
+	}
+
+	return tagged, nil
+}
+
+// nearestQuestion returns the index of the question rated nearest to the
+// player among those not answered yet, or -1 when every one was
+func (s *QuizService) nearestQuestion(ctx context.Context, quizID, playerID string, questions []models.Question, answered map[string]bool) (int, error) {
+	player, err := s.playerRating(ctx, playerID)
+	if err != nil {
+		return 0, err
+	}
+
+	ratings, err := s.questionRatings(ctx, quizID, questions)
+	if err != nil {
+		return 0, err
+	}
+
+	indexes := make([]int, 0, len(questions))
+	values := make([]float64, 0, len(questions))
+	for i, q := range questions {
+		if !answered[q.ID] {
+			indexes = append(indexes, i)
+			values = append(values, ratings[q.ID].Value)
+		}
+	}
+
+	nearest := rating.Nearest(player.Value, values)
+	if nearest < 0 {
+		return -1, nil
+	}
+
+	return indexes[nearest], nil
+}
+
+// nextAdaptiveQuestion moves an adaptive session on to the question rated
+// nearest to the player's new rating. The session is the one read before
+// the answer to answeredID was stored.
+func (s *QuizService) nextAdaptiveQuestion(ctx context.Context, session *models.Session, playerID, answeredID string) (*PlayerQuestion, error) {
+	answered := map[string]bool{answeredID: true}
+	for _, q := range session.Questions {
+		if _, ok := session.Result[models.ResultKey(q.ID, playerID)]; ok {
+			answered[q.ID] = true
+		}
+	}
+
+	next, err := s.nearestQuestion(ctx, session.Quiz.ID, playerID, session.Questions, answered)
+	if err != nil || next < 0 {
+		return nil, err
+	}
+
+	err = s.sessionManager.AppendQuizSessionEvents(ctx, session.ID, models.SessionEvent{
+		Type:          models.SessionEventQuestionChanged,
+		QuestionIndex: next,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to change question: %w", err)
+	}
+
+	var order *models.PlayerOrder
+	if player := findSessionPlayer(session, playerID); player != nil {
+		order = player.Order
+	}
+
+	shown := playerQuestions(session.Questions[next:next+1], order)
+	return &shown[0], nil
+}
+
+// PlayerRating returns the rating of a player
+func (s *QuizService) PlayerRating(ctx context.Context, playerID string) (*models.Rating, error) {
+	player, err := s.playerRating(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &player, nil
+}
+
+// QuestionRatings returns the rating of every question of the latest version
+// of a quiz with the difficulty computed from it next to the authored one
+func (s *QuizService) QuestionRatings(ctx context.Context, quizID string) ([]QuestionRating, error) {
+	quiz, questions, err := s.quizReader.GetQuiz(ctx, quizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quiz: %w", err)
+	}
+
+	if quiz == nil {
+		return nil, ErrQuizNotFound
+	}
+
+	ratings, err := s.questionRatings(ctx, quizID, questions)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]QuestionRating, 0, len(questions))
+	for _, q := range questions {
+		list = append(list, QuestionRating{
+			QuestionID: q.ID,
+			Word:       q.Word,
+			Rating:     ratings[q.ID],
+			Authored:   q.Difficulty,
+			Computed:   rating.Difficulty(ratings[q.ID]),
+		})
+	}
+
+	return list, nil
+}
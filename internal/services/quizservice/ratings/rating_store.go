@@ -0,0 +1,27 @@
+package ratings
+
+import (
+	"context"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+// RatingStore keeps the ratings of players and of the questions of stored
+// quizzes. Questions are rated per quiz, so their rating carries over to
+// every version that keeps the question ID.
+type RatingStore interface {
+	// returns nil when the player has no rating yet
+	FindPlayerRating(ctx context.Context, playerID string) (*models.Rating, error)
+
+	// by question ID, questions without a rating are left out
+	FindQuestionRatings(ctx context.Context, quizID string, questionIDs []string) (map[string]models.Rating, error)
+
+	// UpdateRatings stores the ratings update returns for the current ones,
+	// nil when not rated yet. Concurrent updates of the same ratings do not
+	// overwrite each other, update is called again instead. With an empty
+	// quizID only the player is stored.
+	UpdateRatings(ctx context.Context, playerID, quizID, questionID string, update RatingUpdate) error
+}
+
+// RatingUpdate returns the new ratings of a player and a question
+type RatingUpdate func(player, question *models.Rating) (models.Rating, models.Rating)
@@ -0,0 +1,178 @@
+package redisratingstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice/ratings"
+)
+
+// Redis key patterns
+const (
+	playerRatingsKey   = "ratings:players"           // Hash: player ID -> JSON encoded rating, never expires
+	questionRatingsKey = "ratings:quiz:%s:questions" // Hash: question ID -> JSON encoded rating, never expires
+)
+
+// maxUpdateAttempts bounds how often UpdateRatings reads the ratings again
+// after a concurrent update changed them
+const maxUpdateAttempts = 10
+
+// swapRatingsScript stores a player's and a question's new ratings only if
+// both are still stored as they were read, so concurrent answers cannot
+// overwrite each other's update.
+//
+// KEYS[1] player ratings hash, KEYS[2] question ratings hash
+// ARGV[1] player ID, ARGV[2] question ID, ARGV[3] player rating read,
+// ARGV[4] question rating read, both empty when there was none, ARGV[5] new
+// player rating, ARGV[6] new question rating, the question is left alone
+// when empty. Returns 1 when stored, 0 when a rating changed.
+var swapRatingsScript = redis.NewScript(`
+if (redis.call('HGET', KEYS[1], ARGV[1]) or '') ~= ARGV[3] then
+	return 0
+end
+if ARGV[6] ~= '' then
+	if (redis.call('HGET', KEYS[2], ARGV[2]) or '') ~= ARGV[4] then
+		return 0
+	end
+	redis.call('HSET', KEYS[2], ARGV[2], ARGV[6])
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[5])
+return 1
+`)
+
+type RedisRatingStore struct {
+	rdb *redis.Client
+}
+
+var _ ratings.RatingStore = (*RedisRatingStore)(nil)
+
+func NewRedisRatingStore(redisURL string) (*RedisRatingStore, error) {
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
+	}
+
+	rdb := redis.NewClient(opt)
+
+	ctx := context.Background()
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisRatingStore{rdb: rdb}, nil
+}
+
+func (r *RedisRatingStore) FindPlayerRating(ctx context.Context, playerID string) (*models.Rating, error) {
+	data, err := r.rdb.HGet(ctx, playerRatingsKey, playerID).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var rating models.Rating
+	if err := json.Unmarshal(data, &rating); err != nil {
+		return nil, fmt.Errorf("failed to decode rating: %w", err)
+	}
+
+	return &rating, nil
+}
+
+func (r *RedisRatingStore) FindQuestionRatings(ctx context.Context, quizID string, questionIDs []string) (map[string]models.Rating, error) {
+	found := make(map[string]models.Rating, len(questionIDs))
+	if len(questionIDs) == 0 {
+		return found, nil
+	}
+
+	values, err := r.rdb.HMGet(ctx, fmt.Sprintf(questionRatingsKey, quizID), questionIDs...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question ratings: %w", err)
+	}
+
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var rating models.Rating
+		if err := json.Unmarshal([]byte(data), &rating); err != nil {
+			return nil, fmt.Errorf("failed to decode rating of question %s: %w", questionIDs[i], err)
+		}
+		found[questionIDs[i]] = rating
+	}
+
+	return found, nil
+}
+
+func (r *RedisRatingStore) UpdateRatings(ctx context.Context, playerID, quizID, questionID string, update ratings.RatingUpdate) error {
+	keys := []string{playerRatingsKey, fmt.Sprintf(questionRatingsKey, quizID)}
+
+	for range maxUpdateAttempts {
+		readPlayer, player, err := r.readRating(ctx, keys[0], playerID)
+		if err != nil {
+			return err
+		}
+
+		var readQuestion string
+		var question *models.Rating
+		if quizID != "" {
+			readQuestion, question, err = r.readRating(ctx, keys[1], questionID)
+			if err != nil {
+				return err
+			}
+		}
+
+		updatedPlayer, updatedQuestion := update(player, question)
+
+		playerData, err := json.Marshal(updatedPlayer)
+		if err != nil {
+			return fmt.Errorf("failed to encode rating: %w", err)
+		}
+
+		var questionData []byte
+		if quizID != "" {
+			questionData, err = json.Marshal(updatedQuestion)
+			if err != nil {
+				return fmt.Errorf("failed to encode rating: %w", err)
+			}
+		}
+
+		stored, err := swapRatingsScript.Run(ctx, r.rdb, keys,
+			playerID, questionID, readPlayer, readQuestion, playerData, questionData).Int()
+		if err != nil {
+			return fmt.Errorf("failed to store ratings: %w", err)
+		}
+
+		if stored == 1 {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("failed to store ratings: changed by %d concurrent updates", maxUpdateAttempts)
+}
+
+// readRating returns a rating as stored, empty when there is none, and
+// decoded
+func (r *RedisRatingStore) readRating(ctx context.Context, key, field string) (string, *models.Rating, error) {
+	data, err := r.rdb.HGet(ctx, key, field).Result()
+	if err == redis.Nil {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get rating: %w", err)
+	}
+
+	var rating models.Rating
+	if err := json.Unmarshal([]byte(data), &rating); err != nil {
+		return "", nil, fmt.Errorf("failed to decode rating: %w", err)
+	}
+
+	return data, &rating, nil
+}
@@ -0,0 +1,363 @@
+package quizservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice/sessions"
+)
+
+const (
+	// sessionTimeout ends a session that has been in progress this long, so
+	// its results are saved before the Redis keys expire. Time in the lobby
+	// or paused does not count, the timeout is looked at again every
+	// suspendedCheckInterval meanwhile.
+	sessionTimeout         = 30 * time.Minute
+	suspendedCheckInterval = time.Minute
+
+	// resultsRetryInterval is how long after a failed save the results of a
+	// finished session are saved again
+	resultsRetryInterval = time.Minute
+
+	EndReasonCompleted = "completed"  // every player answered every question
+	EndReasonHostEnded = "host_ended" // ended explicitly
+	EndReasonTimeout   = "timeout"    // sessionTimeout of play elapsed
+	EndReasonClosed    = "closed"     // the quiz schedule closed
+)
+
+// EndSession finishes a running session, saves one QuizResult per player and
+// broadcasts the final standings. Practice results are saved apart and not
+// broadcast. Only the first caller wins, later calls get
+// ErrSessionFinished. Results that fail to save are saved again by the
+// scheduler.
+func (s *QuizService) EndSession(ctx context.Context, sessionID, reason string) ([]*models.QuizResult, error) {
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	changed, err := s.sessionManager.TransitionQuizSessionPhase(
+		ctx,
+		sessionID,
+		models.SessionPhaseFinished,
+		reason,
+		models.SessionPhaseLobby,
+		models.SessionPhaseInProgress,
+		models.SessionPhasePaused,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to finish session: %w", err)
+	}
+
+	if !changed {
+		return nil, ErrSessionFinished
+	}
+
+	return s.finishSession(ctx, sessionID)
+}
+
+// finishSession saves the results of a finished session and sends the final
+// standings to its room. The end deadline is moved up front and only removed
+// once every result is saved, so a failure or crash in between is retried.
+// Results keep their IDs, saving them again replaces them.
+func (s *QuizService) finishSession(ctx context.Context, sessionID string) ([]*models.QuizResult, error) {
+	retry := sessions.Deadline{
+		Kind:      sessions.DeadlineEnd,
+		SessionID: sessionID,
+		At:        time.Now().Add(resultsRetryInterval).Truncate(time.Millisecond),
+	}
+	if err := s.sessionManager.SetDeadline(ctx, retry); err != nil {
+		return nil, err
+	}
+
+	// Re-read so answers that landed before the transition are included
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil || session.FinishedAt == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	if session.QuestionDeadline != nil {
+		if err := s.sessionManager.SetQuestionTimer(ctx, sessionID, session.CurrentQuestion, nil, 0); err != nil {
+			log.Printf("failed to stop question timer: %v", err)
+		}
+	}
+
+	results := rankResults(session, *session.FinishedAt)
+
+	for _, result := range results {
+		save := s.quizWriter.SaveQuizResult
+		if session.Practice {
+			save = s.quizWriter.SavePracticeResult
+		}
+
+		if err := save(ctx, result); err != nil {
+			return nil, fmt.Errorf("failed to save quiz result: %w", err)
+		}
+	}
+
+	if err := s.sessionManager.DeleteDeadline(ctx, sessions.DeadlineEnd, sessionID); err != nil {
+		log.Printf("failed to delete session deadline: %v", err)
+	}
+
+	if session.Practice {
+		return results, nil
+	}
+
+	msg := models.WSMessage{
+		Type: "quiz_ended",
+		Data: map[string]interface{}{
+			"reason":  session.EndReason,
+			"results": results,
+		},
+	}
+
+	if err := s.hub.BroadcastToRoom(ctx, sessionID, msg); err != nil {
+		log.Printf("failed to broadcast quiz end: %v", err)
+	}
+
+	// Nothing is broadcast after the end, the final standings are the last
+	// message connected players get before they are disconnected
+	if err := s.hub.DeleteRoom(sessionID); err != nil {
+		log.Printf("failed to delete room: %v", err)
+	}
+
+	return results, nil
+}
+
+// QuizResults returns every saved result of a quiz, across sessions
+func (s *QuizService) QuizResults(ctx context.Context, quizID string) ([]*models.QuizResult, error) {
+	results, err := s.quizReader.GetQuizResults(ctx, quizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quiz results: %w", err)
+	}
+
+	return results, nil
+}
+
+// scheduleSessionTimeout has the scheduler end the session once
+// sessionTimeout has elapsed, or look at it again then when it was not in
+// progress all along
+func (s *QuizService) scheduleSessionTimeout(ctx context.Context, sessionID string) error {
+	return s.sessionManager.SetDeadline(ctx, sessions.Deadline{
+		Kind:      sessions.DeadlineEnd,
+		SessionID: sessionID,
+		At:        time.Now().Add(sessionTimeout).Truncate(time.Millisecond),
+	})
+}
+
+// runSessionDeadlines ends the sessions that timed out, starts the lobbies
+// whose countdown ran out, saves the results that failed to save before and
+// deletes the rooms of sessions that expired. Called by the scheduler.
+func (s *QuizService) runSessionDeadlines(ctx context.Context, now time.Time) {
+	deadlines, err := s.sessionManager.DueDeadlines(ctx, now)
+	if err != nil {
+		log.Printf("scheduler: %v", err)
+		return
+	}
+
+	for _, deadline := range deadlines {
+		claimed, err := s.sessionManager.ClaimDeadline(ctx, deadline)
+		if err != nil {
+			log.Printf("scheduler: session %s: %v", deadline.SessionID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := s.runSessionDeadline(ctx, deadline); err != nil {
+			log.Printf("scheduler: session %s: %v", deadline.SessionID, err)
+
+			// Tried again later unless the session got a newer deadline, a
+			// lost one would leave the session and its room behind
+			deadline.At = now.Add(resultsRetryInterval).Truncate(time.Millisecond)
+			if _, err := s.sessionManager.AddDeadline(ctx, deadline); err != nil {
+				log.Printf("scheduler: session %s: %v", deadline.SessionID, err)
+			}
+		}
+	}
+}
+
+func (s *QuizService) runSessionDeadline(ctx context.Context, deadline sessions.Deadline) error {
+	session, err := s.sessionManager.FindQuizSession(ctx, deadline.SessionID)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	// Expired with its keys, only the room is left. Sessions that never
+	// finished, or whose results never saved, end up here.
+	if session == nil {
+		if err := s.hub.DeleteRoom(deadline.SessionID); err == nil {
+			log.Printf("deleted room of expired session %s", deadline.SessionID)
+		}
+		return nil
+	}
+
+	if deadline.Kind == sessions.DeadlineLobby {
+		s.autoStart(ctx, session.ID)
+		return nil
+	}
+
+	if session.Phase == models.SessionPhaseFinished {
+		_, err = s.finishSession(ctx, session.ID)
+		return err
+	}
+
+	now := time.Now()
+	left := sessionTimeout - session.RunTime(now)
+	if session.Phase != models.SessionPhaseInProgress {
+		left = max(left, suspendedCheckInterval)
+	}
+
+	if left > 0 {
+		return s.sessionManager.SetDeadline(ctx, sessions.Deadline{
+			Kind:      sessions.DeadlineEnd,
+			SessionID: session.ID,
+			At:        now.Add(left).Truncate(time.Millisecond),
+		})
+	}
+
+	_, err = s.EndSession(ctx, session.ID, EndReasonTimeout)
+	if errors.Is(err, ErrSessionFinished) {
+		return nil
+	}
+	return err
+}
+
+// progressSession moves a session on once every player answered: a hosted
+// session to its next question, any other session to its end. It returns the
+// results when it ended the session.
+func (s *QuizService) progressSession(ctx context.Context, sessionID string) []*models.QuizResult {
+	session, err := s.sessionManager.FindQuizSession(ctx, sessionID)
+	if err != nil || session == nil {
+		return nil
+	}
+
+	if session.Hosted() {
+		question := session.Questions[session.CurrentQuestion]
+		for _, player := range session.Players {
+			if _, ok := session.Result[models.ResultKey(question.ID, player.ID)]; !ok {
+				return nil
+			}
+		}
+
+		if err := s.advanceQuestion(ctx, sessionID, session.CurrentQuestion, false); err != nil {
+			log.Printf("failed to advance session %s: %v", sessionID, err)
+		}
+		return nil
+	}
+
+	asked := len(session.Players) * len(session.Questions)
+	if session.Adaptive {
+		asked = session.AskCount
+	}
+
+	if len(session.Result) < asked {
+		return nil
+	}
+
+	results, err := s.EndSession(ctx, sessionID, EndReasonCompleted)
+	if err != nil && !errors.Is(err, ErrSessionFinished) {
+		log.Printf("failed to end completed session %s: %v", sessionID, err)
+	}
+	return results
+}
+
+// resultID is the same for every save of a player's result in a session
+func resultID(sessionID, playerID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(sessionID+"/"+playerID)).String()
+}
+
+// rankResults orders the players of a session and assigns positions. Ties on
+// score are broken by more correct answers, then less total answer time, then
+// the earlier last answer, and finally by player ID so the order is stable.
+func rankResults(session *models.Session, completedAt time.Time) []*models.QuizResult {
+	type standing struct {
+		player     models.SessionPlayer
+		answers    []models.ResultAnswer
+		correct    int
+		answerTime float64
+		lastAnswer time.Time
+	}
+
+	standings := make([]standing, 0, len(session.Players))
+	for _, player := range session.Players {
+		st := standing{player: player}
+		for _, question := range session.Questions {
+			answer, ok := session.Result[models.ResultKey(question.ID, player.ID)]
+			if !ok && session.Adaptive {
+				// never picked, so never asked
+				continue
+			}
+			if !ok {
+				answer = models.Answer{CorrectAnswer: question.ExpectedAnswer()}
+			}
+			st.answers = append(st.answers, models.ResultAnswer{
+				QuestionID: question.ID,
+				Word:       question.Word,
+				Answer:     answer,
+			})
+			if !ok {
+				continue
+			}
+
+			if answer.Correct {
+				st.correct++
+			}
+			st.answerTime += answer.AnswerTime
+			if answer.AnsweredAt.After(st.lastAnswer) {
+				st.lastAnswer = answer.AnsweredAt
+			}
+		}
+		standings = append(standings, st)
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		switch {
+		case a.player.Score != b.player.Score:
+			return a.player.Score > b.player.Score
+		case a.correct != b.correct:
+			return a.correct > b.correct
+		case a.answerTime != b.answerTime:
+			return a.answerTime < b.answerTime
+		case !a.lastAnswer.Equal(b.lastAnswer):
+			return a.lastAnswer.Before(b.lastAnswer)
+		default:
+			return a.player.ID < b.player.ID
+		}
+	})
+
+	results := make([]*models.QuizResult, 0, len(standings))
+	for i, st := range standings {
+		results = append(results, &models.QuizResult{
+			ID:             resultID(session.ID, st.player.ID),
+			QuizID:         session.Quiz.ID,
+			QuizVersion:    session.Quiz.Version,
+			SessionID:      session.ID,
+			PlayerID:       st.player.ID,
+			Username:       st.player.Username,
+			FinalScore:     st.player.Score,
+			CompletionTime: completedAt,
+			Position:       i + 1,
+			Answers:        st.answers,
+		})
+	}
+
+	return results
+}
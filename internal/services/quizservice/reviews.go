@@ -0,0 +1,152 @@
+package quizservice
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/pkg/quizgen"
+	"wordwizardry/internal/pkg/review"
+	"wordwizardry/internal/services/quizservice/wordbank"
+)
+
+// reviewSize is how many due words a review quiz asks at most by default
+const reviewSize = 10
+
+// recordReview reschedules the word a question is about after a player's
+// answer to it. Questions without a word bank entry have nothing to review.
+// Failures are only logged, they never fail the answer.
+func (s *QuizService) recordReview(ctx context.Context, playerID string, question models.Question, answer models.Answer, timeLimit float64) {
+	if question.WordID == "" {
+		return
+	}
+
+	item, err := s.reviewStore.FindReview(ctx, playerID, question.WordID)
+	if err != nil {
+		log.Printf("failed to find review of word %s: %v", question.WordID, err)
+		return
+	}
+
+	if item == nil {
+		item = &models.ReviewItem{PlayerID: playerID, WordID: question.WordID}
+	}
+
+	review.Schedule(item, review.Quality(answer, timeLimit), answer.AnsweredAt)
+
+	if err := s.reviewStore.SaveReview(ctx, item); err != nil {
+		log.Printf("failed to save review of word %s: %v", question.WordID, err)
+	}
+}
+
+// ReviewQueue lists the words the player is due to review and assembles a
+// quiz from the most overdue ones. The seed in the response gets the same
+// quiz from StartReview while the queue is unchanged. Registered players
+// have to be signed in to see their queue.
+func (s *QuizService) ReviewQueue(ctx context.Context, playerID string, req ReviewRequest) (*ReviewResponse, error) {
+	if _, err := s.reviewingPlayer(ctx, playerID, req); err != nil {
+		return nil, err
+	}
+
+	resp, _, err := s.reviewQuiz(ctx, playerID, req)
+	return resp, err
+}
+
+// StartReview starts a practice session of the player's review quiz. The
+// player answers as playerID, so the answers reschedule the reviewed words.
+// Registered players have to be signed in to review their words.
+func (s *QuizService) StartReview(ctx context.Context, playerID string, req ReviewRequest) (*JoinQuizResponse, error) {
+	player, err := s.reviewingPlayer(ctx, playerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	_, generated, err := s.reviewQuiz(ctx, playerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if generated == nil {
+		return nil, ErrNothingToReview
+	}
+
+	return s.startPractice(ctx, generated.Quiz, generated.Questions, 0, player, 0)
+}
+
+// reviewingPlayer is who reviews the words of playerID, the account when
+// the ID is one
+func (s *QuizService) reviewingPlayer(ctx context.Context, playerID string, req ReviewRequest) (models.Player, error) {
+	account, err := s.actingPlayer(ctx, playerID, req.Token)
+	if err != nil {
+		return models.Player{}, err
+	}
+
+	if account == nil {
+		return models.Player{ID: playerID, Username: req.Username}, nil
+	}
+
+	return models.Player{ID: account.ID, Username: account.Username}, nil
+}
+
+func (s *QuizService) reviewQuiz(ctx context.Context, playerID string, req ReviewRequest) (*ReviewResponse, *quizgen.Result, error) {
+	items, err := s.reviewStore.ListReviews(ctx, playerID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	now := time.Now()
+	resp := &ReviewResponse{
+		PlayerID:  playerID,
+		Tracked:   len(items),
+		Due:       review.Due(items, now),
+		NextDueAt: review.NextDue(items, now),
+	}
+
+	count := req.Count
+	if count <= 0 {
+		count = reviewSize
+	}
+
+	// Words removed from the word bank since are left out
+	targets := make([]*models.Word, 0, count)
+	for _, item := range resp.Due {
+		if len(targets) == count {
+			break
+		}
+
+		word, err := s.wordBank.FindWord(ctx, item.WordID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find word: %w", err)
+		}
+		if word != nil {
+			targets = append(targets, word)
+		}
+	}
+
+	if len(targets) == 0 {
+		return resp, nil, nil
+	}
+
+	pool, err := s.Words(ctx, wordbank.WordFilter{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	generated, err := quizgen.Generate(targets, pool, quizgen.Options{
+		QuizID:  "review_" + playerID,
+		Title:   "Review",
+		Count:   len(targets),
+		Options: req.Options,
+		Seed:    req.Seed,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp.Seed = generated.Seed
+	resp.Quiz = generated.Quiz
+	resp.Questions = playerQuestions(generated.Questions, nil)
+
+	return resp, generated, nil
+}
@@ -0,0 +1,91 @@
+package redisreviewstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/redis/go-redis/v9"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice/reviews"
+)
+
+// Redis key patterns
+const reviewsKey = "player:%s:reviews" // Hash: word ID -> JSON encoded review item, never expires
+
+type RedisReviewStore struct {
+	rdb *redis.Client
+}
+
+var _ reviews.ReviewStore = (*RedisReviewStore)(nil)
+
+func NewRedisReviewStore(redisURL string) (*RedisReviewStore, error) {
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
+	}
+
+	rdb := redis.NewClient(opt)
+
+	ctx := context.Background()
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisReviewStore{rdb: rdb}, nil
+}
+
+func (r *RedisReviewStore) FindReview(ctx context.Context, playerID, wordID string) (*models.ReviewItem, error) {
+	data, err := r.rdb.HGet(ctx, fmt.Sprintf(reviewsKey, playerID), wordID).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var item models.ReviewItem
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, fmt.Errorf("failed to decode review: %w", err)
+	}
+
+	return &item, nil
+}
+
+func (r *RedisReviewStore) ListReviews(ctx context.Context, playerID string) ([]*models.ReviewItem, error) {
+	entries, err := r.rdb.HGetAll(ctx, fmt.Sprintf(reviewsKey, playerID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	list := make([]*models.ReviewItem, 0, len(entries))
+	for wordID, data := range entries {
+		var item models.ReviewItem
+		if err := json.Unmarshal([]byte(data), &item); err != nil {
+			return nil, fmt.Errorf("failed to decode review of word %s: %w", wordID, err)
+		}
+		list = append(list, &item)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].DueAt.Before(list[j].DueAt)
+	})
+
+	return list, nil
+}
+
+func (r *RedisReviewStore) SaveReview(ctx context.Context, item *models.ReviewItem) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to encode review: %w", err)
+	}
+
+	if err := r.rdb.HSet(ctx, fmt.Sprintf(reviewsKey, item.PlayerID), item.WordID, data).Err(); err != nil {
+		return fmt.Errorf("failed to store review: %w", err)
+	}
+
+	return nil
+}
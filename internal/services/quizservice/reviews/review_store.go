@@ -0,0 +1,17 @@
+package reviews
+
+import (
+	"context"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+// ReviewStore keeps the spaced repetition state of every word a player has
+// answered. It lives as long as the player, not the session.
+type ReviewStore interface {
+	// returns nil when the player never answered a question about the word
+	FindReview(ctx context.Context, playerID, wordID string) (*models.ReviewItem, error)
+	// every word of the player, soonest due first
+	ListReviews(ctx context.Context, playerID string) ([]*models.ReviewItem, error)
+	SaveReview(ctx context.Context, item *models.ReviewItem) error
+}
@@ -0,0 +1,232 @@
+package quizservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+// schedulerInterval is how often due schedules and question timers are
+// checked, and so the most a scheduled step or a question close can be late
+const schedulerInterval = time.Second
+
+// RunScheduler opens, starts and closes scheduled quizzes, closes timed out
+// questions and ends timed out sessions until ctx is done. Progress and
+// deadlines are kept in the store, so after a restart anything that came due
+// while the server was down is caught up on the first tick.
+func (s *QuizService) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		s.runDueSchedules(ctx, now)
+		s.runQuestionTimers(ctx, now)
+		s.runSessionDeadlines(ctx, now)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *QuizService) runDueSchedules(ctx context.Context, now time.Time) {
+	list, err := s.scheduleStore.ListSchedules(ctx)
+	if err != nil {
+		log.Printf("scheduler: %v", err)
+		return
+	}
+
+	for _, schedule := range list {
+		if schedule.State == models.QuizScheduleStateClosed {
+			continue
+		}
+
+		if err := s.runDueSchedule(ctx, schedule, now); err != nil {
+			log.Printf("scheduler: quiz %s: %v", schedule.QuizID, err)
+		}
+	}
+}
+
+// runDueSchedule advances a listed schedule. It is read again under
+// scheduleMu and left alone when it was removed or replaced since it was
+// listed, so a stale copy never overwrites a newer schedule.
+func (s *QuizService) runDueSchedule(ctx context.Context, listed *models.QuizSchedule, now time.Time) error {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+
+	schedule, err := s.scheduleStore.FindSchedule(ctx, listed.QuizID)
+	if err != nil {
+		return fmt.Errorf("failed to find schedule: %w", err)
+	}
+
+	if schedule == nil || !schedule.UpdatedAt.Equal(listed.UpdatedAt) {
+		return nil
+	}
+
+	return s.advanceSchedule(ctx, schedule, now)
+}
+
+// advanceSchedule applies every step that is due at now and saves the
+// schedule when it changed. Callers hold scheduleMu.
+func (s *QuizService) advanceSchedule(ctx context.Context, schedule *models.QuizSchedule, now time.Time) error {
+	changed := false
+
+	if schedule.State == models.QuizScheduleStateScheduled && !now.Before(schedule.OpensAt) {
+		schedule.State = models.QuizScheduleStateOpen
+		changed = true
+	}
+
+	closing := schedule.ClosesAt != nil && !now.Before(*schedule.ClosesAt)
+
+	// A start missed entirely while the server was down is skipped, the
+	// window it belonged to is already over
+	if schedule.State == models.QuizScheduleStateOpen && schedule.StartsAt != nil &&
+		schedule.SessionID == "" && !now.Before(*schedule.StartsAt) && !closing {
+		session, err := s.startScheduledSession(ctx, schedule.QuizID)
+		if err != nil {
+			return err
+		}
+		schedule.SessionID = session.ID
+		changed = true
+	}
+
+	if schedule.State == models.QuizScheduleStateOpen && closing {
+		if err := s.closeScheduledQuiz(ctx, schedule.QuizID); err != nil {
+			return err
+		}
+		schedule.State = models.QuizScheduleStateClosed
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	schedule.UpdatedAt = now
+	if err := s.scheduleStore.SaveSchedule(ctx, schedule); err != nil {
+		return fmt.Errorf("failed to save schedule: %w", err)
+	}
+
+	return nil
+}
+
+func (s *QuizService) startScheduledSession(ctx context.Context, quizID string) (*models.Session, error) {
+	quiz, questions, err := s.quizReader.GetPublishedQuiz(ctx, quizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quiz: %w", err)
+	}
+
+	if quiz == nil || len(questions) == 0 {
+		return nil, ErrQuizNotFound
+	}
+
+	return s.createSession(ctx, quiz, questions, nil)
+}
+
+// closeScheduledQuiz ends the sessions still running when the window closes
+func (s *QuizService) closeScheduledQuiz(ctx context.Context, quizID string) error {
+	active, err := s.sessionManager.FindActiveQuizSessions(ctx, quizID)
+	if err != nil {
+		return fmt.Errorf("failed to find sessions: %w", err)
+	}
+
+	for _, session := range active {
+		_, err = s.EndSession(ctx, session.ID, EndReasonClosed)
+		if err != nil && !errors.Is(err, ErrSessionFinished) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ScheduleQuiz sets or replaces the schedule of a quiz. The scheduler starts
+// over from the new times, steps that are already due are applied right away.
+func (s *QuizService) ScheduleQuiz(ctx context.Context, quizID string, req ScheduleQuizRequest) (*models.QuizSchedule, error) {
+	if _, err := s.latestVersion(ctx, quizID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	opensAt := now
+	if req.OpensAt != nil {
+		opensAt = *req.OpensAt
+	}
+
+	if req.ClosesAt != nil && !req.ClosesAt.After(opensAt) {
+		return nil, fmt.Errorf("%w: closes_at must be after opens_at", ErrInvalidSchedule)
+	}
+
+	if req.StartsAt != nil {
+		if req.StartsAt.Before(opensAt) {
+			return nil, fmt.Errorf("%w: starts_at must not be before opens_at", ErrInvalidSchedule)
+		}
+		if req.ClosesAt != nil && !req.StartsAt.Before(*req.ClosesAt) {
+			return nil, fmt.Errorf("%w: starts_at must be before closes_at", ErrInvalidSchedule)
+		}
+	}
+
+	schedule := &models.QuizSchedule{
+		QuizID:    quizID,
+		OpensAt:   opensAt,
+		ClosesAt:  req.ClosesAt,
+		StartsAt:  req.StartsAt,
+		State:     models.QuizScheduleStateScheduled,
+		UpdatedAt: now,
+	}
+
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+
+	if err := s.scheduleStore.SaveSchedule(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to save schedule: %w", err)
+	}
+
+	if err := s.advanceSchedule(ctx, schedule, now); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (s *QuizService) QuizSchedule(ctx context.Context, quizID string) (*models.QuizSchedule, error) {
+	schedule, err := s.scheduleStore.FindSchedule(ctx, quizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find schedule: %w", err)
+	}
+
+	if schedule == nil {
+		return nil, ErrScheduleNotFound
+	}
+
+	return schedule, nil
+}
+
+func (s *QuizService) QuizSchedules(ctx context.Context) ([]*models.QuizSchedule, error) {
+	return s.scheduleStore.ListSchedules(ctx)
+}
+
+// UnscheduleQuiz removes the schedule, the quiz is always open again. A
+// session the scheduler started keeps running.
+func (s *QuizService) UnscheduleQuiz(ctx context.Context, quizID string) error {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+
+	schedule, err := s.scheduleStore.FindSchedule(ctx, quizID)
+	if err != nil {
+		return fmt.Errorf("failed to find schedule: %w", err)
+	}
+
+	if schedule == nil {
+		return ErrScheduleNotFound
+	}
+
+	return s.scheduleStore.DeleteSchedule(ctx, quizID)
+}
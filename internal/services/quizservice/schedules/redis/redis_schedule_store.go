@@ -0,0 +1,99 @@
+package redisschedulestore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/redis/go-redis/v9"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice/schedules"
+)
+
+// Redis key patterns
+const schedulesKey = "quiz:schedules" // Hash: quiz ID -> JSON encoded schedule, never expires
+
+type RedisScheduleStore struct {
+	rdb *redis.Client
+}
+
+var _ schedules.ScheduleStore = (*RedisScheduleStore)(nil)
+
+func NewRedisScheduleStore(redisURL string) (*RedisScheduleStore, error) {
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
+	}
+
+	rdb := redis.NewClient(opt)
+
+	ctx := context.Background()
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisScheduleStore{rdb: rdb}, nil
+}
+
+func (r *RedisScheduleStore) FindSchedule(ctx context.Context, quizID string) (*models.QuizSchedule, error) {
+	data, err := r.rdb.HGet(ctx, schedulesKey, quizID).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var schedule models.QuizSchedule
+	if err := json.Unmarshal(data, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+func (r *RedisScheduleStore) ListSchedules(ctx context.Context) ([]*models.QuizSchedule, error) {
+	entries, err := r.rdb.HGetAll(ctx, schedulesKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	list := make([]*models.QuizSchedule, 0, len(entries))
+	for quizID, data := range entries {
+		var schedule models.QuizSchedule
+		if err := json.Unmarshal([]byte(data), &schedule); err != nil {
+			return nil, fmt.Errorf("failed to decode schedule of quiz %s: %w", quizID, err)
+		}
+		list = append(list, &schedule)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].OpensAt.Before(list[j].OpensAt)
+	})
+
+	return list, nil
+}
+
+func (r *RedisScheduleStore) SaveSchedule(ctx context.Context, schedule *models.QuizSchedule) error {
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to encode schedule: %w", err)
+	}
+
+	if err := r.rdb.HSet(ctx, schedulesKey, schedule.QuizID, data).Err(); err != nil {
+		return fmt.Errorf("failed to store schedule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RedisScheduleStore) DeleteSchedule(ctx context.Context, quizID string) error {
+	if err := r.rdb.HDel(ctx, schedulesKey, quizID).Err(); err != nil {
+		return fmt.Errorf("failed to delete schedule: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package schedules
+
+import (
+	"context"
+	"wordwizardry/internal/pkg/models"
+)
+
+// ScheduleStore keeps quiz schedules, including the scheduler's progress, so
+// a restart picks up where it left off
+type ScheduleStore interface {
+	// returns nil when the quiz has no schedule
+	FindSchedule(ctx context.Context, quizID string) (*models.QuizSchedule, error)
+	ListSchedules(ctx context.Context) ([]*models.QuizSchedule, error)
+	SaveSchedule(ctx context.Context, schedule *models.QuizSchedule) error
+	DeleteSchedule(ctx context.Context, quizID string) error
+}
@@ -1,22 +1,54 @@
 package quizservice
 
-const (
-	baseScore     = 100 // base score for correct answer
-	maxAnswerTime = 5.0 // maximum time in seconds
-	minMultiplier = 0.1 // minimum score multiplier
-	perfectTime   = 3.0 // time for maximum score
+import (
+	"sort"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice/scoring"
 )
 
-// Add scoring calculation function
-func calculateScore(answerTime float64) int {
-	if answerTime <= perfectTime {
-		return baseScore
+// scoreAnswer scores a player's answer with the scorer of the session's
+// quiz, filling in what the session knows about the player's earlier answers
+func scoreAnswer(session *models.Session, questionID, playerID string, attempt scoring.Attempt) []models.PointsPart {
+	var cfg *models.ScoringConfig
+	if session.Quiz != nil {
+		cfg = session.Quiz.Scoring
+	}
+
+	attempt.Streak = correctStreak(session, playerID)
+	attempt.FirstCorrect = !answeredCorrectly(session, questionID)
+	return scoring.New(cfg).Score(attempt)
+}
+
+// correctStreak counts the correct answers in a row a player gave last
+func correctStreak(session *models.Session, playerID string) int {
+	answers := make([]models.Answer, 0)
+	for key, answer := range session.Result {
+		if _, id := models.ParseResultKey(key); id == playerID {
+			answers = append(answers, answer)
+		}
 	}
 
-	if answerTime >= maxAnswerTime {
-		return int(float64(baseScore) * minMultiplier)
+	sort.Slice(answers, func(i, j int) bool {
+		return answers[i].AnsweredAt.After(answers[j].AnsweredAt)
+	})
+
+	streak := 0
+	for _, answer := range answers {
+		if !answer.Correct {
+			break
+		}
+		streak++
 	}
+	return streak
+}
 
-	multiplier := 1.0 - ((answerTime-perfectTime)/(maxAnswerTime-perfectTime))*(1.0-minMultiplier)
-	return int(float64(baseScore) * multiplier)
+// answeredCorrectly reports whether any player answered the question correctly
+func answeredCorrectly(session *models.Session, questionID string) bool {
+	for key, answer := range session.Result {
+		if id, _ := models.ParseResultKey(key); id == questionID && answer.Correct {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,230 @@
+package scoring
+
+import (
+	"math"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+const (
+	BaseScore        = 100 // points for a correct answer before any adjustment
+	DefaultMaxStreak = 5   // longest streak counted when the quiz sets none
+
+	minMultiplier    = 0.1 // share of the points left at the time limit
+	perfectTimeRatio = 0.6 // share of the time limit that still earns the full points
+)
+
+// Attempt is what a scorer knows about an answer
+type Attempt struct {
+	Correct    bool
+	TimedOut   bool
+	AnswerTime float64 // seconds
+	TimeLimit  float64 // seconds
+	Credit     float64 // share of the points earned, below 1 for typed answers with typos
+	Weight     float64 // how hard the question is rated, 1 for an average one
+
+	Streak       int  // correct answers in a row the player gave right before this one
+	FirstCorrect bool // no other player answered the question correctly yet
+}
+
+// Scorer turns an answer into points. The parts tell the player why they got
+// them, their sum is the answer's points.
+type Scorer interface {
+	Score(attempt Attempt) []models.PointsPart
+}
+
+// New builds the scorer a quiz is configured for. Quizzes without a config
+// use linear decay, as before scoring was configurable.
+func New(cfg *models.ScoringConfig) Scorer {
+	if cfg == nil {
+		return PartialCredit{Next: Linear{}}
+	}
+
+	var scorer Scorer
+	switch cfg.Strategy {
+	case models.ScoringExponential:
+		scorer = Exponential{}
+	case models.ScoringFlat:
+		scorer = Flat{}
+	default:
+		scorer = Linear{}
+	}
+	scorer = PartialCredit{Next: scorer}
+
+	if cfg.DifficultyWeighted {
+		scorer = DifficultyWeight{Next: scorer}
+	}
+
+	if cfg.StreakBonus > 0 {
+		maxStreak := cfg.MaxStreak
+		if maxStreak <= 0 {
+			maxStreak = DefaultMaxStreak
+		}
+		scorer = Streak{Next: scorer, Bonus: cfg.StreakBonus, Max: maxStreak}
+	}
+
+	if cfg.FirstCorrectBonus > 0 {
+		scorer = FirstCorrect{Next: scorer, Bonus: cfg.FirstCorrectBonus}
+	}
+
+	if cfg.WrongPenalty > 0 {
+		scorer = NegativeMarking{Next: scorer, Penalty: cfg.WrongPenalty}
+	}
+
+	return scorer
+}
+
+// Total sums the parts of a breakdown
+func Total(parts []models.PointsPart) int {
+	total := 0
+	for _, part := range parts {
+		total += part.Points
+	}
+	return total
+}
+
+// Linear keeps the full points for the first perfectTimeRatio of the time
+// limit, then takes them down linearly to minMultiplier at the limit
+type Linear struct{}
+
+func (Linear) Score(a Attempt) []models.PointsPart {
+	if !a.Correct {
+		return nil
+	}
+
+	return speedParts(a, func(late float64) float64 {
+		return 1.0 - late*(1.0-minMultiplier)
+	})
+}
+
+// Exponential takes the points down from the full points to minMultiplier
+// like Linear, but most of the loss comes right after the perfect time
+type Exponential struct{}
+
+func (Exponential) Score(a Attempt) []models.PointsPart {
+	if !a.Correct {
+		return nil
+	}
+
+	return speedParts(a, func(late float64) float64 {
+		return math.Pow(minMultiplier, late)
+	})
+}
+
+// Flat gives every correct answer the same points however long it took
+type Flat struct{}
+
+func (Flat) Score(a Attempt) []models.PointsPart {
+	if !a.Correct {
+		return nil
+	}
+
+	return []models.PointsPart{{Rule: "correct", Points: BaseScore}}
+}
+
+// speedParts scores a correct answer with a speed deduction. decay maps how
+// late the answer was, from 0 at the perfect time to 1 at the limit, to the
+// share of the points kept.
+func speedParts(a Attempt, decay func(late float64) float64) []models.PointsPart {
+	parts := []models.PointsPart{{Rule: "correct", Points: BaseScore}}
+
+	perfectTime := a.TimeLimit * perfectTimeRatio
+	if a.AnswerTime <= perfectTime {
+		return parts
+	}
+
+	multiplier := minMultiplier
+	if a.AnswerTime < a.TimeLimit {
+		multiplier = decay((a.AnswerTime - perfectTime) / (a.TimeLimit - perfectTime))
+	}
+
+	if deduction := BaseScore - int(float64(BaseScore)*multiplier); deduction > 0 {
+		parts = append(parts, models.PointsPart{Rule: "speed", Points: -deduction})
+	}
+	return parts
+}
+
+// PartialCredit takes away the share of the points a correct answer did not
+// earn, e.g. half of them for a typed answer with a typo
+type PartialCredit struct {
+	Next Scorer
+}
+
+func (p PartialCredit) Score(a Attempt) []models.PointsPart {
+	parts := p.Next.Score(a)
+	if !a.Correct || a.Credit <= 0 || a.Credit >= 1 {
+		return parts
+	}
+
+	if deduction := int(float64(Total(parts)) * (1 - a.Credit)); deduction > 0 {
+		parts = append(parts, models.PointsPart{Rule: "partial_credit", Points: -deduction})
+	}
+	return parts
+}
+
+// DifficultyWeight scales the points of a correct answer by the weight of
+// the question, hard questions earn more than easy ones
+type DifficultyWeight struct {
+	Next Scorer
+}
+
+func (d DifficultyWeight) Score(a Attempt) []models.PointsPart {
+	parts := d.Next.Score(a)
+	if !a.Correct || a.Weight <= 0 || a.Weight == 1 {
+		return parts
+	}
+
+	if change := int(float64(Total(parts)) * (a.Weight - 1)); change != 0 {
+		parts = append(parts, models.PointsPart{Rule: "difficulty", Points: change})
+	}
+	return parts
+}
+
+// Streak raises the points of a correct answer by Bonus for every correct
+// answer in a row before it, counting at most Max of them
+type Streak struct {
+	Next  Scorer
+	Bonus float64
+	Max   int
+}
+
+func (s Streak) Score(a Attempt) []models.PointsPart {
+	parts := s.Next.Score(a)
+	if !a.Correct || a.Streak == 0 {
+		return parts
+	}
+
+	streak := min(a.Streak, s.Max)
+	if bonus := int(float64(Total(parts)) * s.Bonus * float64(streak)); bonus > 0 {
+		parts = append(parts, models.PointsPart{Rule: "streak", Points: bonus})
+	}
+	return parts
+}
+
+// FirstCorrect adds Bonus to the first correct answer to each question
+type FirstCorrect struct {
+	Next  Scorer
+	Bonus int
+}
+
+func (f FirstCorrect) Score(a Attempt) []models.PointsPart {
+	parts := f.Next.Score(a)
+	if a.Correct && a.FirstCorrect {
+		parts = append(parts, models.PointsPart{Rule: "first_correct", Points: f.Bonus})
+	}
+	return parts
+}
+
+// NegativeMarking takes Penalty points for a wrong answer. Questions left
+// unanswered cost nothing, so guessing is not worth it.
+type NegativeMarking struct {
+	Next    Scorer
+	Penalty int
+}
+
+func (n NegativeMarking) Score(a Attempt) []models.PointsPart {
+	if !a.Correct && !a.TimedOut {
+		return []models.PointsPart{{Rule: "wrong", Points: -n.Penalty}}
+	}
+	return n.Next.Score(a)
+}
@@ -0,0 +1,161 @@
+package scoring
+
+import (
+	"slices"
+	"testing"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+func TestScore(t *testing.T) {
+	fast := Attempt{Correct: true, AnswerTime: 2, TimeLimit: 10, Credit: 1, Weight: 1}
+
+	with := func(change func(a *Attempt)) Attempt {
+		a := fast
+		change(&a)
+		return a
+	}
+	slow := with(func(a *Attempt) { a.AnswerTime = 8 })
+	wrong := with(func(a *Attempt) { a.Correct = false })
+
+	tests := []struct {
+		name    string
+		cfg     *models.ScoringConfig
+		attempt Attempt
+		want    []models.PointsPart
+	}{
+		{
+			name:    "default within the perfect time",
+			attempt: fast,
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}},
+		},
+		{
+			name:    "default decays linearly",
+			attempt: slow,
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}, {Rule: "speed", Points: -45}},
+		},
+		{
+			name:    "default past the time limit",
+			attempt: with(func(a *Attempt) { a.AnswerTime = 12 }),
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}, {Rule: "speed", Points: -90}},
+		},
+		{
+			name:    "default wrong answer",
+			attempt: wrong,
+		},
+		{
+			name:    "exponential decays faster",
+			cfg:     &models.ScoringConfig{Strategy: models.ScoringExponential},
+			attempt: slow,
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}, {Rule: "speed", Points: -69}},
+		},
+		{
+			name:    "flat ignores the time",
+			cfg:     &models.ScoringConfig{Strategy: models.ScoringFlat},
+			attempt: slow,
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}},
+		},
+		{
+			name:    "partial credit after the speed deduction",
+			attempt: with(func(a *Attempt) { a.AnswerTime = 8; a.Credit = 0.5 }),
+			want: []models.PointsPart{
+				{Rule: "correct", Points: 100},
+				{Rule: "speed", Points: -45},
+				{Rule: "partial_credit", Points: -27},
+			},
+		},
+		{
+			name:    "streak bonus per earlier correct answer",
+			cfg:     &models.ScoringConfig{StreakBonus: 0.1},
+			attempt: with(func(a *Attempt) { a.Streak = 3 }),
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}, {Rule: "streak", Points: 30}},
+		},
+		{
+			name:    "streak capped by the default max",
+			cfg:     &models.ScoringConfig{StreakBonus: 0.1},
+			attempt: with(func(a *Attempt) { a.Streak = 8 }),
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}, {Rule: "streak", Points: 50}},
+		},
+		{
+			name:    "streak capped by the quiz max",
+			cfg:     &models.ScoringConfig{StreakBonus: 0.1, MaxStreak: 2},
+			attempt: with(func(a *Attempt) { a.Streak = 8 }),
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}, {Rule: "streak", Points: 20}},
+		},
+		{
+			name:    "streak does not save a wrong answer",
+			cfg:     &models.ScoringConfig{StreakBonus: 0.1},
+			attempt: with(func(a *Attempt) { a.Correct = false; a.Streak = 3 }),
+		},
+		{
+			name:    "first correct answer",
+			cfg:     &models.ScoringConfig{FirstCorrectBonus: 50},
+			attempt: with(func(a *Attempt) { a.FirstCorrect = true }),
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}, {Rule: "first_correct", Points: 50}},
+		},
+		{
+			name:    "later correct answer",
+			cfg:     &models.ScoringConfig{FirstCorrectBonus: 50},
+			attempt: fast,
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}},
+		},
+		{
+			name:    "negative marking of a wrong answer",
+			cfg:     &models.ScoringConfig{WrongPenalty: 25, FirstCorrectBonus: 50},
+			attempt: with(func(a *Attempt) { a.Correct = false; a.FirstCorrect = true }),
+			want:    []models.PointsPart{{Rule: "wrong", Points: -25}},
+		},
+		{
+			name:    "negative marking spares unanswered questions",
+			cfg:     &models.ScoringConfig{WrongPenalty: 25},
+			attempt: with(func(a *Attempt) { a.Correct = false; a.TimedOut = true }),
+		},
+		{
+			name:    "negative marking passes correct answers on",
+			cfg:     &models.ScoringConfig{WrongPenalty: 25},
+			attempt: fast,
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}},
+		},
+		{
+			name:    "easy question weighted down",
+			cfg:     &models.ScoringConfig{DifficultyWeighted: true},
+			attempt: with(func(a *Attempt) { a.Weight = 0.5 }),
+			want:    []models.PointsPart{{Rule: "correct", Points: 100}, {Rule: "difficulty", Points: -50}},
+		},
+		{
+			name: "every decorator in order",
+			cfg: &models.ScoringConfig{
+				DifficultyWeighted: true,
+				StreakBonus:        0.1,
+				FirstCorrectBonus:  10,
+				WrongPenalty:       25,
+			},
+			attempt: with(func(a *Attempt) { a.Weight = 2; a.Streak = 2; a.FirstCorrect = true }),
+			want: []models.PointsPart{
+				{Rule: "correct", Points: 100},
+				{Rule: "difficulty", Points: 100},
+				{Rule: "streak", Points: 40},
+				{Rule: "first_correct", Points: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.cfg).Score(tt.attempt)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTotal(t *testing.T) {
+	parts := []models.PointsPart{{Rule: "correct", Points: 100}, {Rule: "speed", Points: -45}, {Rule: "streak", Points: 11}}
+	if got := Total(parts); got != 66 {
+		t.Errorf("Total() = %d, want 66", got)
+	}
+	if got := Total(nil); got != 0 {
+		t.Errorf("Total(nil) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,114 @@
+package quizservice
+
+import (
+	"context"
+	"fmt"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice/sessions"
+)
+
+// SessionEvents returns the full event log of a session, in order. Until the
+// session finished only its host gets the log, and without the answer key
+// or any answers.
+func (s *QuizService) SessionEvents(ctx context.Context, sessionID, hostKey string) ([]models.SessionEvent, error) {
+	events, err := s.sessionManager.FindQuizSessionEvents(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session events: %w", err)
+	}
+
+	if len(events) == 0 {
+		return nil, ErrSessionNotFound
+	}
+
+	session := sessions.Fold(events)
+	if session == nil {
+		return nil, fmt.Errorf("session log is missing its creation event")
+	}
+
+	live, err := liveSessionAccess(session, hostKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if live {
+		events = withoutAnswers(events)
+	}
+
+	return events, nil
+}
+
+// ReplaySession rebuilds the session as it was right after the event with
+// the given sequence number. A zero seq replays the whole log. The log is
+// read as SessionEvents serves it.
+func (s *QuizService) ReplaySession(ctx context.Context, sessionID, hostKey string, seq int64) (*SessionReplayResponse, error) {
+	events, err := s.SessionEvents(ctx, sessionID, hostKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if seq > 0 && seq < int64(len(events)) {
+		events = events[:seq]
+	}
+
+	session := sessions.Fold(events)
+	if session == nil {
+		return nil, fmt.Errorf("session log is missing its creation event")
+	}
+
+	return &SessionReplayResponse{
+		Seq:         events[len(events)-1].Seq,
+		Session:     session,
+		Leaderboard: sessions.Leaderboard(session),
+	}, nil
+}
+
+// liveSessionAccess reports whether a session has not finished yet, which
+// only its host may look into. ErrSessionRunning is returned to anyone else.
+func liveSessionAccess(session *models.Session, hostKey string) (bool, error) {
+	if session.Phase == models.SessionPhaseFinished {
+		return false, nil
+	}
+
+	if hostKey == "" {
+		return false, ErrSessionRunning
+	}
+
+	if !hostKeyMatches(session, hostKey) {
+		return false, ErrNotHost
+	}
+
+	return true, nil
+}
+
+// withoutAnswers copies a live session's log with the answer key taken out
+// of the questions and the answers out of the answer events. Scores stay.
+func withoutAnswers(events []models.SessionEvent) []models.SessionEvent {
+	redacted := make([]models.SessionEvent, len(events))
+	for i, e := range events {
+		if e.Session != nil {
+			created := *e.Session
+			created.Questions = withoutAnswerKey(created.Questions)
+			e.Session = &created
+		}
+		e.Answer = nil
+		redacted[i] = e
+	}
+	return redacted
+}
+
+// withoutAnswerKey copies questions leaving out everything that gives their
+// answer away
+func withoutAnswerKey(questions []models.Question) []models.Question {
+	redacted := make([]models.Question, len(questions))
+	for i, q := range questions {
+		q.Correct = ""
+		q.Explanation = ""
+		q.Pairs = nil
+		if q.Kind().Typed() {
+			q.Word = ""
+		}
+		redacted[i] = q
+	}
+	return redacted
+}
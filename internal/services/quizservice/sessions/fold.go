@@ -0,0 +1,142 @@
+package sessions
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+// Fold rebuilds a session by applying its events in order. It returns nil
+// when the log does not start with a session_created event. Unknown event
+// types are skipped so older readers can still fold newer logs.
+func Fold(events []models.SessionEvent) *models.Session {
+	var session *models.Session
+
+	for _, e := range events {
+		if e.Type == models.SessionEventCreated {
+			if e.Session == nil {
+				continue
+			}
+
+			created := *e.Session
+			created.Players = []models.SessionPlayer{}
+			created.Result = make(models.Result)
+			session = &created
+			trackRunTime(session, session.Phase, e.OccurredAt)
+			continue
+		}
+
+		if session == nil {
+			continue
+		}
+
+		Apply(session, e)
+	}
+
+	return session
+}
+
+// Apply folds a single event into an existing session
+func Apply(session *models.Session, e models.SessionEvent) {
+	switch e.Type {
+	case models.SessionEventPlayerJoined:
+		if e.Player == nil || findPlayer(session, e.Player.ID) >= 0 {
+			return
+		}
+		session.Players = append(session.Players, *e.Player)
+
+	case models.SessionEventAnswerSubmitted:
+		// The first answer is the one that was scored
+		key := models.ResultKey(e.QuestionID, e.PlayerID)
+		if _, answered := session.Result[key]; e.Answer == nil || answered {
+			return
+		}
+		session.Result[key] = *e.Answer
+
+	case models.SessionEventScoreChanged:
+		if i := findPlayer(session, e.PlayerID); i >= 0 {
+			session.Players[i].Score += e.Score
+		}
+
+	case models.SessionEventPhaseChanged:
+		trackRunTime(session, e.Phase, e.OccurredAt)
+		session.Phase = e.Phase
+		if e.Phase == models.SessionPhaseFinished {
+			finishedAt := e.OccurredAt
+			session.FinishedAt = &finishedAt
+			session.EndReason = e.Reason
+		}
+
+	case models.SessionEventPlayerRemoved:
+		if i := findPlayer(session, e.PlayerID); i >= 0 {
+			session.Players = append(session.Players[:i], session.Players[i+1:]...)
+		}
+		if e.Reason == models.RemovedKicked && !slices.Contains(session.Kicked, e.PlayerID) {
+			session.Kicked = append(session.Kicked, e.PlayerID)
+		}
+
+	case models.SessionEventPlayerMuted:
+		if i := findPlayer(session, e.PlayerID); i >= 0 {
+			session.Players[i].Muted = e.Muted
+		}
+
+	case models.SessionEventPlayerReady:
+		if i := findPlayer(session, e.PlayerID); i >= 0 {
+			session.Players[i].Ready = e.Ready
+		}
+
+	case models.SessionEventQuestionChanged:
+		session.CurrentQuestion = e.QuestionIndex
+
+	case models.SessionEventQuestionTimer:
+		session.QuestionDeadline = e.Deadline
+		session.TimeRemaining = e.Remaining
+
+	case models.SessionEventQuestionOpened:
+		// Answers are timed from the first time the question was opened
+		key := models.ResultKey(e.QuestionID, e.PlayerID)
+		if _, opened := session.Opened[key]; opened {
+			return
+		}
+		if session.Opened == nil {
+			session.Opened = make(map[string]time.Time)
+		}
+		session.Opened[key] = e.OccurredAt
+	}
+}
+
+// Leaderboard returns the session players sorted by score. Players with the
+// same score keep the order in which they joined.
+func Leaderboard(session *models.Session) []models.SessionPlayer {
+	players := make([]models.SessionPlayer, len(session.Players))
+	copy(players, session.Players)
+
+	sort.SliceStable(players, func(i, j int) bool {
+		return players[i].Score > players[j].Score
+	})
+
+	return players
+}
+
+// trackRunTime starts the clock of the session's run time when it goes in
+// progress at and stops it when it leaves
+func trackRunTime(session *models.Session, phase models.SessionPhase, at time.Time) {
+	switch {
+	case phase == models.SessionPhaseInProgress && session.RunningSince == nil:
+		session.RunningSince = &at
+	case phase != models.SessionPhaseInProgress && session.RunningSince != nil:
+		session.Ran += at.Sub(*session.RunningSince).Seconds()
+		session.RunningSince = nil
+	}
+}
+
+func findPlayer(session *models.Session, playerID string) int {
+	for i, p := range session.Players {
+		if p.ID == playerID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,319 @@
+package sessions
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+var foldStart = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// at is the time the given seconds after foldStart
+func at(seconds int) time.Time {
+	return foldStart.Add(time.Duration(seconds) * time.Second)
+}
+
+func created(phase models.SessionPhase) models.SessionEvent {
+	return models.SessionEvent{
+		Type:       models.SessionEventCreated,
+		OccurredAt: foldStart,
+		Session: &models.Session{
+			ID:      "s1",
+			Phase:   phase,
+			Players: []models.SessionPlayer{{Player: models.Player{ID: "stale"}}},
+			Result:  models.Result{"q1:stale": {}},
+		},
+	}
+}
+
+func joined(playerID string) models.SessionEvent {
+	return models.SessionEvent{
+		Type:     models.SessionEventPlayerJoined,
+		PlayerID: playerID,
+		Player:   &models.SessionPlayer{Player: models.Player{ID: playerID, Username: "name-" + playerID}},
+	}
+}
+
+func removed(playerID, reason string) models.SessionEvent {
+	return models.SessionEvent{Type: models.SessionEventPlayerRemoved, PlayerID: playerID, Reason: reason}
+}
+
+func scored(playerID string, delta int) models.SessionEvent {
+	return models.SessionEvent{Type: models.SessionEventScoreChanged, PlayerID: playerID, Score: delta}
+}
+
+func phase(p models.SessionPhase, seconds int) models.SessionEvent {
+	return models.SessionEvent{Type: models.SessionEventPhaseChanged, Phase: p, OccurredAt: at(seconds)}
+}
+
+func playerIDs(session *models.Session) []string {
+	ids := make([]string, 0, len(session.Players))
+	for _, p := range session.Players {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func TestFold(t *testing.T) {
+	deadline := at(30)
+
+	tests := []struct {
+		name   string
+		events []models.SessionEvent
+		check  func(t *testing.T, session *models.Session)
+	}{
+		{
+			name:   "no creation event",
+			events: []models.SessionEvent{joined("p1"), scored("p1", 100)},
+			check: func(t *testing.T, session *models.Session) {
+				if session != nil {
+					t.Errorf("session = %+v, want nil", session)
+				}
+			},
+		},
+		{
+			name:   "events before creation are skipped",
+			events: []models.SessionEvent{joined("p1"), created(models.SessionPhaseLobby), joined("p2")},
+			check: func(t *testing.T, session *models.Session) {
+				if ids := playerIDs(session); !slices.Equal(ids, []string{"p2"}) {
+					t.Errorf("players = %v, want [p2]", ids)
+				}
+				if len(session.Result) != 0 {
+					t.Errorf("result = %v, want none", session.Result)
+				}
+			},
+		},
+		{
+			name:   "player joins once",
+			events: []models.SessionEvent{created(models.SessionPhaseLobby), joined("p1"), joined("p2"), joined("p1")},
+			check: func(t *testing.T, session *models.Session) {
+				if ids := playerIDs(session); !slices.Equal(ids, []string{"p1", "p2"}) {
+					t.Errorf("players = %v, want [p1 p2]", ids)
+				}
+			},
+		},
+		{
+			name: "first answer is kept",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseInProgress),
+				joined("p1"),
+				{Type: models.SessionEventAnswerSubmitted, PlayerID: "p1", QuestionID: "q1"},
+				{Type: models.SessionEventAnswerSubmitted, PlayerID: "p1", QuestionID: "q1", Answer: &models.Answer{PlayerChoice: "first"}},
+				{Type: models.SessionEventAnswerSubmitted, PlayerID: "p1", QuestionID: "q1", Answer: &models.Answer{PlayerChoice: "second"}},
+			},
+			check: func(t *testing.T, session *models.Session) {
+				if got := session.Result[models.ResultKey("q1", "p1")].PlayerChoice; got != "first" {
+					t.Errorf("answer = %q, want first", got)
+				}
+			},
+		},
+		{
+			name: "scores add up",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseInProgress),
+				joined("p1"),
+				scored("p1", 100),
+				scored("p1", 50),
+				scored("p1", -25),
+				scored("p2", 100),
+			},
+			check: func(t *testing.T, session *models.Session) {
+				if got := session.Players[0].Score; got != 125 {
+					t.Errorf("score = %d, want 125", got)
+				}
+			},
+		},
+		{
+			name: "finish",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseInProgress),
+				{Type: models.SessionEventPhaseChanged, Phase: models.SessionPhaseFinished, Reason: "timeout", OccurredAt: at(60)},
+			},
+			check: func(t *testing.T, session *models.Session) {
+				if session.Phase != models.SessionPhaseFinished || session.EndReason != "timeout" {
+					t.Errorf("phase = %s, reason = %q", session.Phase, session.EndReason)
+				}
+				if session.FinishedAt == nil || !session.FinishedAt.Equal(at(60)) {
+					t.Errorf("finished at = %v, want %v", session.FinishedAt, at(60))
+				}
+			},
+		},
+		{
+			name: "player removed",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseLobby),
+				joined("p1"),
+				joined("p2"),
+				removed("p1", ""),
+				removed("p3", ""),
+			},
+			check: func(t *testing.T, session *models.Session) {
+				if ids := playerIDs(session); !slices.Equal(ids, []string{"p2"}) {
+					t.Errorf("players = %v, want [p2]", ids)
+				}
+				if len(session.Kicked) != 0 {
+					t.Errorf("kicked = %v, want none", session.Kicked)
+				}
+			},
+		},
+		{
+			name: "removed player rejoins without their score",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseInProgress),
+				joined("p1"),
+				scored("p1", 100),
+				removed("p1", ""),
+				scored("p1", 50),
+				joined("p1"),
+			},
+			check: func(t *testing.T, session *models.Session) {
+				if ids := playerIDs(session); !slices.Equal(ids, []string{"p1"}) {
+					t.Fatalf("players = %v, want [p1]", ids)
+				}
+				if got := session.Players[0].Score; got != 0 {
+					t.Errorf("score = %d, want 0", got)
+				}
+			},
+		},
+		{
+			name: "kicked players are remembered",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseLobby),
+				joined("p1"),
+				joined("p2"),
+				removed("p1", models.RemovedKicked),
+				joined("p1"),
+				removed("p1", models.RemovedKicked),
+			},
+			check: func(t *testing.T, session *models.Session) {
+				if !slices.Equal(session.Kicked, []string{"p1"}) {
+					t.Errorf("kicked = %v, want [p1]", session.Kicked)
+				}
+				if ids := playerIDs(session); !slices.Equal(ids, []string{"p2"}) {
+					t.Errorf("players = %v, want [p2]", ids)
+				}
+			},
+		},
+		{
+			name: "muted and ready",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseLobby),
+				joined("p1"),
+				joined("p2"),
+				{Type: models.SessionEventPlayerMuted, PlayerID: "p1", Muted: true},
+				{Type: models.SessionEventPlayerReady, PlayerID: "p2", Ready: true},
+				{Type: models.SessionEventPlayerReady, PlayerID: "p1", Ready: true},
+				{Type: models.SessionEventPlayerReady, PlayerID: "p1", Ready: false},
+			},
+			check: func(t *testing.T, session *models.Session) {
+				p1, p2 := session.Players[0], session.Players[1]
+				if !p1.Muted || p1.Ready {
+					t.Errorf("p1 muted = %v, ready = %v, want true, false", p1.Muted, p1.Ready)
+				}
+				if p2.Muted || !p2.Ready {
+					t.Errorf("p2 muted = %v, ready = %v, want false, true", p2.Muted, p2.Ready)
+				}
+			},
+		},
+		{
+			name: "question and timer",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseInProgress),
+				{Type: models.SessionEventQuestionChanged, QuestionIndex: 2},
+				{Type: models.SessionEventQuestionTimer, QuestionIndex: 2, Deadline: &deadline},
+				{Type: models.SessionEventQuestionTimer, QuestionIndex: 2, Remaining: 12.5},
+			},
+			check: func(t *testing.T, session *models.Session) {
+				if session.CurrentQuestion != 2 {
+					t.Errorf("current question = %d, want 2", session.CurrentQuestion)
+				}
+				if session.QuestionDeadline != nil || session.TimeRemaining != 12.5 {
+					t.Errorf("deadline = %v, remaining = %v, want nil, 12.5", session.QuestionDeadline, session.TimeRemaining)
+				}
+			},
+		},
+		{
+			name: "question opened the first time",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseInProgress),
+				{Type: models.SessionEventQuestionOpened, PlayerID: "p1", QuestionID: "q1", OccurredAt: at(5)},
+				{Type: models.SessionEventQuestionOpened, PlayerID: "p1", QuestionID: "q1", OccurredAt: at(9)},
+			},
+			check: func(t *testing.T, session *models.Session) {
+				if got := session.Opened[models.ResultKey("q1", "p1")]; !got.Equal(at(5)) {
+					t.Errorf("opened = %v, want %v", got, at(5))
+				}
+			},
+		},
+		{
+			name: "unknown events are skipped",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseLobby),
+				{Type: "player_levelled_up", PlayerID: "p1"},
+				joined("p1"),
+			},
+			check: func(t *testing.T, session *models.Session) {
+				if ids := playerIDs(session); !slices.Equal(ids, []string{"p1"}) {
+					t.Errorf("players = %v, want [p1]", ids)
+				}
+			},
+		},
+		{
+			name: "run time leaves out the lobby and pauses",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseLobby),
+				phase(models.SessionPhaseInProgress, 10),
+				phase(models.SessionPhasePaused, 40),
+				phase(models.SessionPhaseInProgress, 100),
+			},
+			check: func(t *testing.T, session *models.Session) {
+				if got := session.RunTime(at(130)); got != 60*time.Second {
+					t.Errorf("run time = %v, want 1m0s", got)
+				}
+			},
+		},
+		{
+			name: "run time stops when finished",
+			events: []models.SessionEvent{
+				created(models.SessionPhaseInProgress),
+				phase(models.SessionPhasePaused, 20),
+				phase(models.SessionPhaseInProgress, 50),
+				phase(models.SessionPhaseFinished, 80),
+			},
+			check: func(t *testing.T, session *models.Session) {
+				if session.RunningSince != nil {
+					t.Errorf("running since = %v, want nil", session.RunningSince)
+				}
+				if got := session.RunTime(at(500)); got != 50*time.Second {
+					t.Errorf("run time = %v, want 50s", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, Fold(tt.events))
+		})
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	session := Fold([]models.SessionEvent{
+		created(models.SessionPhaseInProgress),
+		joined("p1"),
+		joined("p2"),
+		joined("p3"),
+		scored("p2", 100),
+		scored("p3", 100),
+	})
+
+	if ids := playerIDs(&models.Session{Players: Leaderboard(session)}); !slices.Equal(ids, []string{"p2", "p3", "p1"}) {
+		t.Errorf("leaderboard = %v, want [p2 p3 p1]", ids)
+	}
+	if ids := playerIDs(session); !slices.Equal(ids, []string{"p1", "p2", "p3"}) {
+		t.Errorf("session players reordered to %v", ids)
+	}
+}
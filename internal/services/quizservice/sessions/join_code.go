@@ -0,0 +1,44 @@
+package sessions
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// JoinCodeLength is the number of digits in a join code. A million codes are
+// plenty for the sessions alive at any one time, guessing is rate limited.
+const JoinCodeLength = 6
+
+var joinCodeSpace = big.NewInt(1_000_000)
+
+// GenerateJoinCode returns a random code of JoinCodeLength digits. Codes are
+// not predictable from each other, uniqueness is up to the store.
+func GenerateJoinCode() (string, error) {
+	n, err := rand.Int(rand.Reader, joinCodeSpace)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate join code: %w", err)
+	}
+	return fmt.Sprintf("%0*d", JoinCodeLength, n.Int64()), nil
+}
+
+// NormalizeJoinCode drops the spaces and dashes people type to group digits,
+// e.g. "123 456" or "123-456"
+func NormalizeJoinCode(code string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code))
+}
+
+// IsJoinCode reports whether a normalized code is well formed, so malformed
+// input can be rejected without a lookup
+func IsJoinCode(code string) bool {
+	if len(code) != JoinCodeLength {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
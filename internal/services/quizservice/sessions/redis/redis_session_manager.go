@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,13 +19,136 @@ import (
 
 const (
 	// Redis key patterns
-	sessionKey     = "quiz:session:%s"         // Hash: Stores quiz session data
-	playersKey     = "quiz:session:%s:players" // Hash: Stores players in a quiz
-	quizIDKey      = "quiz:index:quizid:%s"    // String: Stores session ID for a quiz ID
-	leaderboardKey = "quiz:session:%s:scores"  // Sorted Set: Stores scores for ranking
-	sessionTTL     = 24 * time.Hour            // TTL for all keys
+	eventsKey  = "quiz:session:%s:events"        // Stream: Ordered log of session events
+	phaseKey   = "quiz:session:%s:phase"         // String: Current phase, guards transitions
+	playersKey = "quiz:session:%s:players"       // Set: Player IDs, guards capacity
+	answersKey = "quiz:session:%s:answers"       // Set: Result keys answered, guards re-submits
+	activeKey  = "quiz:index:quizid:%s:sessions" // Set: IDs of the quiz's sessions that may still be running
+	codeKey    = "quiz:index:code:%s"            // String: Session ID for a join code
+	sessionTTL = 24 * time.Hour                  // TTL for all keys
+
+	timersKey    = "quiz:timers"    // Sorted set: Session IDs scored by question deadline in unix ms
+	deadlinesKey = "quiz:deadlines" // Sorted set: <kind>:<session ID> scored by deadline in unix ms
+
+	attemptsKey   = "quiz:joincode:attempts:%s" // String: Join code lookups of a client that found nothing
+	attemptWindow = 10 * time.Minute            // Attempts are counted over this window
+
+	// joinCodeAttempts is how many random codes are tried before giving up,
+	// a collision is rare unless most codes are taken
+	joinCodeAttempts = 10
+
+	eventField = "event" // Stream entry field holding the JSON encoded event
 )
 
+// transitionPhaseScript sets the phase and logs the phase_changed event only
+// when the current phase is one of the allowed ones, so concurrent callers
+// (e.g. the last answer and a timeout) cannot both finish a session.
+//
+// KEYS[1] phase key, KEYS[2] events stream
+// ARGV[1] new phase, ARGV[2] encoded event, ARGV[3..] allowed current phases
+var transitionPhaseScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+for i = 3, #ARGV do
+	if current == ARGV[i] then
+		redis.call('SET', KEYS[1], ARGV[1], 'KEEPTTL')
+		redis.call('XADD', KEYS[2], '*', 'event', ARGV[2])
+		return 1
+	end
+end
+return 0
+`)
+
+// addPlayerScript logs the player_joined event only when the session has room
+// for the player, so concurrent joins cannot go over capacity.
+//
+// KEYS[1] players set, KEYS[2] events stream
+// ARGV[1] player ID, ARGV[2] max players (0 is unlimited), ARGV[3] encoded
+// event, ARGV[4] TTL in seconds
+var addPlayerScript = redis.NewScript(`
+local max = tonumber(ARGV[2])
+if max > 0 and redis.call('SCARD', KEYS[1]) >= max then
+	return 0
+end
+redis.call('SADD', KEYS[1], ARGV[1])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+redis.call('XADD', KEYS[2], '*', 'event', ARGV[3])
+redis.call('EXPIRE', KEYS[2], ARGV[4])
+return 1
+`)
+
+// saveAnswersScript logs answers and the score they earned only when the
+// player has none of the questions answered yet, so a re-submit racing the
+// first answer cannot be stored or scored twice.
+//
+// KEYS[1] answers set, KEYS[2] events stream
+// ARGV[1] TTL in seconds, ARGV[2] number of result keys n, ARGV[3..2+n]
+// result keys, ARGV[3+n..] encoded events
+var saveAnswersScript = redis.NewScript(`
+local n = tonumber(ARGV[2])
+for i = 3, 2 + n do
+	if redis.call('SISMEMBER', KEYS[1], ARGV[i]) == 1 then
+		return 0
+	end
+end
+for i = 3, 2 + n do
+	redis.call('SADD', KEYS[1], ARGV[i])
+end
+for i = 3 + n, #ARGV do
+	redis.call('XADD', KEYS[2], '*', 'event', ARGV[i])
+end
+redis.call('EXPIRE', KEYS[1], ARGV[1])
+redis.call('EXPIRE', KEYS[2], ARGV[1])
+return 1
+`)
+
+// closeQuestionScript logs a miss for every player that has not answered
+// the question and then the question_closed event. Misses share the answers
+// set with saveAnswersScript, so a late answer and a miss cannot both count.
+//
+// KEYS[1] answers set, KEYS[2] events stream
+// ARGV[1] TTL in seconds, ARGV[2] number of misses n, ARGV[3..2+n] result
+// keys, ARGV[3+n..2+2n] encoded misses, ARGV[3+2n] encoded closed event.
+// Returns the positions of the misses that were logged, from 1.
+var closeQuestionScript = redis.NewScript(`
+local n = tonumber(ARGV[2])
+local logged = {}
+for i = 1, n do
+	if redis.call('SADD', KEYS[1], ARGV[2 + i]) == 1 then
+		redis.call('XADD', KEYS[2], '*', 'event', ARGV[2 + n + i])
+		table.insert(logged, i)
+	end
+end
+redis.call('XADD', KEYS[2], '*', 'event', ARGV[3 + 2 * n])
+redis.call('EXPIRE', KEYS[1], ARGV[1])
+redis.call('EXPIRE', KEYS[2], ARGV[1])
+return logged
+`)
+
+// forgetAttemptScript takes back a counted join code attempt. A window that
+// expired in between is not started again.
+//
+// KEYS[1] attempts key
+var forgetAttemptScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.call('DECR', KEYS[1])
+end
+return 0
+`)
+
+// claimTimerScript removes a question timer or a session deadline only if it
+// still has the deadline the caller saw, one moved in between is left alone.
+//
+// KEYS[1] timers or deadlines set
+// ARGV[1] member, ARGV[2] deadline in unix ms
+var claimTimerScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if score and tonumber(score) == tonumber(ARGV[2]) then
+	redis.call('ZREM', KEYS[1], ARGV[1])
+	return 1
+end
+return 0
+`)
+
 type RedisSessionManager struct {
 	rdb *redis.Client
 }
@@ -46,35 +172,126 @@ func NewRedisSessionManager(redisURL string) (*RedisSessionManager, error) {
 }
 
 func (r *RedisSessionManager) FindQuizSession(ctx context.Context, sessionID string) (*models.Session, error) {
-	sessionData, err := r.rdb.HGetAll(ctx, fmt.Sprintf(sessionKey, sessionID)).Result()
+	events, err := r.FindQuizSessionEvents(ctx, sessionID)
 	if err != nil {
-		if err == redis.Nil {
-			return nil, nil
+		return nil, err
+	}
+
+	session := sessions.Fold(events)
+	if session == nil {
+		return nil, nil
+	}
+	session.Players = sessions.Leaderboard(session)
+
+	return session, nil
+}
+
+// FindActiveQuizSessions reads the quiz's session set and drops the members
+// that finished or expired since they were added
+func (r *RedisSessionManager) FindActiveQuizSessions(ctx context.Context, quizID string) ([]*models.Session, error) {
+	key := fmt.Sprintf(activeKey, quizID)
+	sessionIDs, err := r.rdb.SMembers(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quiz sessions: %w", err)
+	}
+
+	active := make([]*models.Session, 0, len(sessionIDs))
+	var stale []interface{}
+
+	for _, sessionID := range sessionIDs {
+		session, err := r.FindQuizSession(ctx, sessionID)
+		if err != nil {
+			return nil, err
 		}
 
-		return nil, fmt.Errorf("failed to get session: %w", err)
+		if session == nil || session.Phase == models.SessionPhaseFinished {
+			stale = append(stale, sessionID)
+			continue
+		}
+
+		active = append(active, session)
 	}
-	if len(sessionData) == 0 {
-		return nil, nil
+
+	if len(stale) > 0 {
+		if err := r.rdb.SRem(ctx, key, stale...).Err(); err != nil {
+			return nil, fmt.Errorf("failed to prune quiz sessions: %w", err)
+		}
 	}
 
-	var session models.Session
-	if err := json.Unmarshal([]byte(sessionData["data"]), &session); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].CreatedAt.Before(active[j].CreatedAt)
+	})
+
+	return active, nil
+}
+
+func (r *RedisSessionManager) CreateQuizSession(ctx context.Context, session *models.Session) error {
+	session.ID = uuid.New().String()
+	session.CreatedAt = time.Now()
+
+	if !session.Practice {
+		code, err := r.reserveJoinCode(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		session.JoinCode = code
 	}
 
-	players, err := r.getSessionPlayers(ctx, sessionID)
+	created := *session
+	created.Players = nil
+	created.Result = nil
+
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if err := r.appendEvents(ctx, pipe, session.ID, models.SessionEvent{
+			Type:    models.SessionEventCreated,
+			Session: &created,
+		}); err != nil {
+			return err
+		}
+
+		pipe.Set(ctx, fmt.Sprintf(phaseKey, session.ID), string(session.Phase), sessionTTL)
+
+		if session.Practice {
+			return nil
+		}
+
+		// Index the session under its quiz, the set lives as long as its
+		// newest session
+		key := fmt.Sprintf(activeKey, session.Quiz.ID)
+		pipe.SAdd(ctx, key, session.ID)
+		pipe.Expire(ctx, key, sessionTTL)
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to store session: %w", err)
 	}
-	session.Players = players
 
-	return &session, nil
+	return nil
 }
 
-func (r *RedisSessionManager) FindQuizSessionByQuizID(ctx context.Context, quizID string) (*models.Session, error) {
-	key := fmt.Sprintf(quizIDKey, quizID)
-	sessionID, err := r.rdb.Get(ctx, key).Result()
+// reserveJoinCode claims a free code for the session. The code expires with
+// the session keys.
+func (r *RedisSessionManager) reserveJoinCode(ctx context.Context, sessionID string) (string, error) {
+	for attempt := 0; attempt < joinCodeAttempts; attempt++ {
+		code, err := sessions.GenerateJoinCode()
+		if err != nil {
+			return "", err
+		}
+
+		ok, err := r.rdb.SetNX(ctx, fmt.Sprintf(codeKey, code), sessionID, sessionTTL).Result()
+		if err != nil {
+			return "", fmt.Errorf("failed to reserve join code: %w", err)
+		}
+		if ok {
+			return code, nil
+		}
+	}
+
+	return "", fmt.Errorf("no free join code after %d attempts", joinCodeAttempts)
+}
+
+func (r *RedisSessionManager) FindQuizSessionByJoinCode(ctx context.Context, code string) (*models.Session, error) {
+	sessionID, err := r.rdb.Get(ctx, fmt.Sprintf(codeKey, code)).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return nil, nil
@@ -86,58 +303,75 @@ func (r *RedisSessionManager) FindQuizSessionByQuizID(ctx context.Context, quizI
 	return r.FindQuizSession(ctx, sessionID)
 }
 
-func (r *RedisSessionManager) CreateQuizSession(ctx context.Context, session *models.Session) error {
-	session.ID = uuid.New().String()
+func (r *RedisSessionManager) CountJoinCodeAttempt(ctx context.Context, client string) (int64, error) {
+	key := fmt.Sprintf(attemptsKey, client)
 
-	sessionData, err := json.Marshal(session)
+	var incr *redis.IntCmd
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		// The window starts at the first attempt and is not extended
+		pipe.ExpireNX(ctx, key, attemptWindow)
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal session: %w", err)
+		return 0, fmt.Errorf("failed to count join code attempt: %w", err)
 	}
 
-	// Store session data
-	key := fmt.Sprintf(sessionKey, session.ID)
-	err = r.rdb.HSet(ctx, key, "data", sessionData).Err()
-	if err != nil {
-		return fmt.Errorf("failed to store session: %w", err)
-	}
+	return incr.Val(), nil
+}
 
-	// Create index from quiz ID to session ID
-	indexKey := fmt.Sprintf(quizIDKey, session.Quiz.ID)
-	err = r.rdb.Set(ctx, indexKey, session.ID, sessionTTL).Err()
-	if err != nil {
-		return fmt.Errorf("failed to create quiz ID index: %w", err)
+func (r *RedisSessionManager) ForgetJoinCodeAttempt(ctx context.Context, client string) error {
+	err := forgetAttemptScript.Run(ctx, r.rdb, []string{fmt.Sprintf(attemptsKey, client)}).Err()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to forget join code attempt: %w", err)
 	}
 
-	// Set TTL
-	r.rdb.Expire(ctx, key, sessionTTL)
-	r.rdb.Expire(ctx, indexKey, sessionTTL)
-	r.rdb.Expire(ctx, fmt.Sprintf(playersKey, session.ID), sessionTTL)
-	r.rdb.Expire(ctx, fmt.Sprintf(leaderboardKey, session.ID), sessionTTL)
-
 	return nil
 }
 
-func (r *RedisSessionManager) AddPlayerToQuizSession(ctx context.Context, sessionID string, player models.SessionPlayer) error {
-	playerData, err := json.Marshal(player)
+func (r *RedisSessionManager) AddPlayerToQuizSession(ctx context.Context, sessionID string, player models.SessionPlayer, maxPlayers int) error {
+	data, err := encodeEvent(models.SessionEvent{
+		Type:     models.SessionEventPlayerJoined,
+		PlayerID: player.ID,
+		Player:   &player,
+	}, time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to marshal player: %w", err)
+		return err
+	}
+
+	keys := []string{
+		fmt.Sprintf(playersKey, sessionID),
+		fmt.Sprintf(eventsKey, sessionID),
 	}
 
-	// Add to players hash
-	playersKey := fmt.Sprintf(playersKey, sessionID)
-	err = r.rdb.HSet(ctx, playersKey, player.ID, playerData).Err()
+	added, err := addPlayerScript.Run(ctx, r.rdb, keys, player.ID, maxPlayers, data, int(sessionTTL.Seconds())).Int()
 	if err != nil {
 		return fmt.Errorf("failed to add player: %w", err)
 	}
 
-	// Initialize score in leaderboard
-	leaderboardKey := fmt.Sprintf(leaderboardKey, sessionID)
-	err = r.rdb.ZAdd(ctx, leaderboardKey, redis.Z{
-		Score:  0,
-		Member: player.ID,
-	}).Err()
+	if added == 0 {
+		return sessions.ErrSessionFull
+	}
+
+	return nil
+}
+
+func (r *RedisSessionManager) RemovePlayerFromQuizSession(ctx context.Context, sessionID, playerID, reason string) error {
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if err := r.appendEvents(ctx, pipe, sessionID, models.SessionEvent{
+			Type:     models.SessionEventPlayerRemoved,
+			PlayerID: playerID,
+			Reason:   reason,
+		}); err != nil {
+			return err
+		}
+
+		// Frees the place for someone else
+		pipe.SRem(ctx, fmt.Sprintf(playersKey, sessionID), playerID)
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to initialize player score: %w", err)
+		return fmt.Errorf("failed to remove player: %w", err)
 	}
 
 	return nil
@@ -168,84 +402,351 @@ func (r *RedisSessionManager) FindQuizPlayerSession(ctx context.Context, session
 }
 
 func (r *RedisSessionManager) UpdateQuizPlayerScoreSession(ctx context.Context, sessionID, playerID string, score int, res models.Result) error {
-	leaderboardKey := fmt.Sprintf(leaderboardKey, sessionID)
-	err := r.rdb.ZIncrBy(ctx, leaderboardKey, float64(score), playerID).Err()
+	resultKeys := make([]interface{}, 0, len(res))
+	events := make([]models.SessionEvent, 0, len(res)+1)
+	for key, answer := range res {
+		answer := answer
+		questionID, _ := models.ParseResultKey(key)
+		resultKeys = append(resultKeys, key)
+		events = append(events, models.SessionEvent{
+			Type:       models.SessionEventAnswerSubmitted,
+			PlayerID:   playerID,
+			QuestionID: questionID,
+			Answer:     &answer,
+		})
+	}
+
+	if score != 0 {
+		events = append(events, models.SessionEvent{
+			Type:     models.SessionEventScoreChanged,
+			PlayerID: playerID,
+			Score:    score,
+		})
+	}
+
+	args := append([]interface{}{int(sessionTTL.Seconds()), len(resultKeys)}, resultKeys...)
+	now := time.Now()
+	for _, event := range events {
+		data, err := encodeEvent(event, now)
+		if err != nil {
+			return err
+		}
+		args = append(args, data)
+	}
+
+	keys := []string{
+		fmt.Sprintf(answersKey, sessionID),
+		fmt.Sprintf(eventsKey, sessionID),
+	}
+
+	saved, err := saveAnswersScript.Run(ctx, r.rdb, keys, args...).Int()
 	if err != nil {
 		return fmt.Errorf("failed to update score: %w", err)
 	}
 
-	playersKey := fmt.Sprintf(playersKey, sessionID)
-	playerData, err := r.rdb.HGet(ctx, playersKey, playerID).Result()
+	if saved == 0 {
+		return sessions.ErrAlreadyAnswered
+	}
+
+	return nil
+}
+
+func (r *RedisSessionManager) FindLeaderboardQuizSession(ctx context.Context, sessionID string) ([]models.SessionPlayer, error) {
+	events, err := r.FindQuizSessionEvents(ctx, sessionID)
 	if err != nil {
-		return fmt.Errorf("failed to get player data: %w", err)
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
 	}
 
-	var player models.SessionPlayer
-	if err := json.Unmarshal([]byte(playerData), &player); err != nil {
-		return fmt.Errorf("failed to unmarshal player: %w", err)
+	session := sessions.Fold(events)
+	if session == nil {
+		return []models.SessionPlayer{}, nil
 	}
 
-	player.Score += score
+	return sessions.Leaderboard(session), nil
+}
 
-	updatedPlayerData, err := json.Marshal(player)
+func (r *RedisSessionManager) TransitionQuizSessionPhase(ctx context.Context, sessionID string, to models.SessionPhase, reason string, from ...models.SessionPhase) (bool, error) {
+	data, err := json.Marshal(models.SessionEvent{
+		Type:       models.SessionEventPhaseChanged,
+		OccurredAt: time.Now(),
+		Phase:      to,
+		Reason:     reason,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal updated player: %w", err)
+		return false, fmt.Errorf("failed to marshal session event: %w", err)
 	}
 
-	err = r.rdb.HSet(ctx, playersKey, playerID, updatedPlayerData).Err()
+	args := make([]interface{}, 0, len(from)+2)
+	args = append(args, string(to), data)
+	for _, phase := range from {
+		args = append(args, string(phase))
+	}
+
+	keys := []string{
+		fmt.Sprintf(phaseKey, sessionID),
+		fmt.Sprintf(eventsKey, sessionID),
+	}
+
+	changed, err := transitionPhaseScript.Run(ctx, r.rdb, keys, args...).Int()
 	if err != nil {
-		return fmt.Errorf("failed to save updated player: %w", err)
+		return false, fmt.Errorf("failed to change session phase: %w", err)
+	}
+
+	return changed == 1, nil
+}
+
+func (r *RedisSessionManager) SetQuestionTimer(ctx context.Context, sessionID string, questionIndex int, deadline *time.Time, remaining float64) error {
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if err := r.appendEvents(ctx, pipe, sessionID, models.SessionEvent{
+			Type:          models.SessionEventQuestionTimer,
+			QuestionIndex: questionIndex,
+			Deadline:      deadline,
+			Remaining:     remaining,
+		}); err != nil {
+			return err
+		}
+
+		if deadline == nil {
+			pipe.ZRem(ctx, timersKey, sessionID)
+			return nil
+		}
+
+		pipe.ZAdd(ctx, timersKey, redis.Z{
+			Score:  float64(deadline.UnixMilli()),
+			Member: sessionID,
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set question timer: %w", err)
 	}
 
 	return nil
 }
 
-func (r *RedisSessionManager) FindLeaderboardQuizSession(ctx context.Context, sessionID string) ([]models.SessionPlayer, error) {
-	leaderboardKey := fmt.Sprintf(leaderboardKey, sessionID)
-	playersWithScores, err := r.rdb.ZRevRangeWithScores(ctx, leaderboardKey, 0, -1).Result()
+func (r *RedisSessionManager) ListQuestionTimers(ctx context.Context) ([]sessions.QuestionTimer, error) {
+	entries, err := r.rdb.ZRangeWithScores(ctx, timersKey, 0, -1).Result()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+		return nil, fmt.Errorf("failed to list question timers: %w", err)
 	}
 
-	playersKey := fmt.Sprintf(playersKey, sessionID)
-	players := make([]models.SessionPlayer, 0, len(playersWithScores))
+	timers := make([]sessions.QuestionTimer, 0, len(entries))
+	for _, entry := range entries {
+		sessionID, _ := entry.Member.(string)
+		timers = append(timers, sessions.QuestionTimer{
+			SessionID: sessionID,
+			Deadline:  time.UnixMilli(int64(entry.Score)),
+		})
+	}
+
+	return timers, nil
+}
+
+func (r *RedisSessionManager) ClaimQuestionTimer(ctx context.Context, timer sessions.QuestionTimer) (bool, error) {
+	claimed, err := claimTimerScript.Run(ctx, r.rdb, []string{timersKey}, timer.SessionID, timer.Deadline.UnixMilli()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim question timer: %w", err)
+	}
+
+	return claimed == 1, nil
+}
+
+func (r *RedisSessionManager) ReleaseQuestionTimer(ctx context.Context, timer sessions.QuestionTimer) error {
+	err := r.rdb.ZAddNX(ctx, timersKey, redis.Z{
+		Score:  float64(timer.Deadline.UnixMilli()),
+		Member: timer.SessionID,
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to release question timer: %w", err)
+	}
 
-	for _, playerScore := range playersWithScores {
-		playerID := playerScore.Member.(string)
-		playerData, err := r.rdb.HGet(ctx, playersKey, playerID).Result()
+	return nil
+}
+
+func (r *RedisSessionManager) CloseQuestion(ctx context.Context, sessionID string, misses []models.SessionEvent, closed models.SessionEvent) ([]models.SessionEvent, error) {
+	now := time.Now()
+	args := []interface{}{int(sessionTTL.Seconds()), len(misses)}
+	for _, miss := range misses {
+		args = append(args, models.ResultKey(miss.QuestionID, miss.PlayerID))
+	}
+	for _, event := range slices.Concat(misses, []models.SessionEvent{closed}) {
+		data, err := encodeEvent(event, now)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get player data: %w", err)
+			return nil, err
 		}
+		args = append(args, data)
+	}
 
-		var player models.SessionPlayer
-		if err := json.Unmarshal([]byte(playerData), &player); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal player: %w", err)
-		}
+	keys := []string{
+		fmt.Sprintf(answersKey, sessionID),
+		fmt.Sprintf(eventsKey, sessionID),
+	}
+
+	positions, err := closeQuestionScript.Run(ctx, r.rdb, keys, args...).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to close question: %w", err)
+	}
+
+	logged := make([]models.SessionEvent, 0, len(positions))
+	for _, i := range positions {
+		logged = append(logged, misses[i-1])
+	}
 
-		players = append(players, player)
+	return logged, nil
+}
+
+func (r *RedisSessionManager) SetDeadline(ctx context.Context, deadline sessions.Deadline) error {
+	err := r.rdb.ZAdd(ctx, deadlinesKey, redis.Z{
+		Score:  float64(deadline.At.UnixMilli()),
+		Member: deadlineMember(deadline.Kind, deadline.SessionID),
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set session deadline: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RedisSessionManager) AddDeadline(ctx context.Context, deadline sessions.Deadline) (bool, error) {
+	added, err := r.rdb.ZAddNX(ctx, deadlinesKey, redis.Z{
+		Score:  float64(deadline.At.UnixMilli()),
+		Member: deadlineMember(deadline.Kind, deadline.SessionID),
+	}).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to add session deadline: %w", err)
 	}
 
-	return players, nil
+	return added == 1, nil
 }
 
-func (r *RedisSessionManager) getSessionPlayers(ctx context.Context, sessionID string) ([]models.SessionPlayer, error) {
-	playersData, err := r.rdb.HGetAll(ctx, fmt.Sprintf(playersKey, sessionID)).Result()
+func (r *RedisSessionManager) DueDeadlines(ctx context.Context, now time.Time) ([]sessions.Deadline, error) {
+	entries, err := r.rdb.ZRangeByScoreWithScores(ctx, deadlinesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.UnixMilli(), 10),
+	}).Result()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get players: %w", err)
+		return nil, fmt.Errorf("failed to list session deadlines: %w", err)
 	}
 
-	players := make([]models.SessionPlayer, 0, len(playersData))
-	for _, data := range playersData {
-		var player models.SessionPlayer
-		if err := json.Unmarshal([]byte(data), &player); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal player: %w", err)
+	deadlines := make([]sessions.Deadline, 0, len(entries))
+	for _, entry := range entries {
+		member, _ := entry.Member.(string)
+		kind, sessionID, ok := strings.Cut(member, ":")
+		if !ok {
+			continue
 		}
-		players = append(players, player)
+
+		deadlines = append(deadlines, sessions.Deadline{
+			Kind:      sessions.DeadlineKind(kind),
+			SessionID: sessionID,
+			At:        time.UnixMilli(int64(entry.Score)),
+		})
+	}
+
+	return deadlines, nil
+}
+
+func (r *RedisSessionManager) ClaimDeadline(ctx context.Context, deadline sessions.Deadline) (bool, error) {
+	member := deadlineMember(deadline.Kind, deadline.SessionID)
+	claimed, err := claimTimerScript.Run(ctx, r.rdb, []string{deadlinesKey}, member, deadline.At.UnixMilli()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim session deadline: %w", err)
 	}
 
-	sort.Slice(players, func(i, j int) bool {
-		return players[i].Score > players[j].Score
+	return claimed == 1, nil
+}
+
+func (r *RedisSessionManager) DeleteDeadline(ctx context.Context, kind sessions.DeadlineKind, sessionID string) error {
+	if err := r.rdb.ZRem(ctx, deadlinesKey, deadlineMember(kind, sessionID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete session deadline: %w", err)
+	}
+
+	return nil
+}
+
+func deadlineMember(kind sessions.DeadlineKind, sessionID string) string {
+	return string(kind) + ":" + sessionID
+}
+
+func (r *RedisSessionManager) AppendQuizSessionEvents(ctx context.Context, sessionID string, events ...models.SessionEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		return r.appendEvents(ctx, pipe, sessionID, events...)
 	})
+	if err != nil {
+		return fmt.Errorf("failed to append session events: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RedisSessionManager) FindQuizSessionEvents(ctx context.Context, sessionID string) ([]models.SessionEvent, error) {
+	entries, err := r.rdb.XRange(ctx, fmt.Sprintf(eventsKey, sessionID), "-", "+").Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get session events: %w", err)
+	}
+
+	events := make([]models.SessionEvent, 0, len(entries))
+	for i, entry := range entries {
+		data, ok := entry.Values[eventField].(string)
+		if !ok {
+			return nil, fmt.Errorf("malformed session event %s", entry.ID)
+		}
+
+		var event models.SessionEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal session event: %w", err)
+		}
+
+		// The stream order is the source of truth for the sequence number
+		event.Seq = int64(i + 1)
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// appendEvents queues the events on a transaction pipeline and refreshes the
+// log TTL so it lives as long as the most recent activity.
+func (r *RedisSessionManager) appendEvents(ctx context.Context, pipe redis.Pipeliner, sessionID string, events ...models.SessionEvent) error {
+	key := fmt.Sprintf(eventsKey, sessionID)
+	now := time.Now()
+
+	for _, event := range events {
+		data, err := encodeEvent(event, now)
+		if err != nil {
+			return err
+		}
+
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: key,
+			Values: map[string]interface{}{eventField: data},
+		})
+	}
+
+	pipe.Expire(ctx, key, sessionTTL)
+	return nil
+}
+
+// encodeEvent prepares an event for the stream. The sequence number is never
+// stored, it is the position in the stream.
+func encodeEvent(event models.SessionEvent, now time.Time) ([]byte, error) {
+	event.Seq = 0
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = now
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal session event: %w", err)
+	}
 
-	return players, nil
+	return data, nil
 }
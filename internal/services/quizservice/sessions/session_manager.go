@@ -2,21 +2,110 @@ package sessions
 
 import (
 	"context"
+	"errors"
+	"time"
+
 	"wordwizardry/internal/pkg/models"
 )
 
+var (
+	// ErrSessionFull is returned when a session has no room for another player
+	ErrSessionFull = errors.New("session is full")
+	// ErrAlreadyAnswered is returned when a player answers a question twice
+	ErrAlreadyAnswered = errors.New("question already answered")
+)
+
+// QuestionTimer is the deadline of the current question of a session
+type QuestionTimer struct {
+	SessionID string
+	Deadline  time.Time
+}
+
+// DeadlineKind tells what is due for a session at a Deadline
+type DeadlineKind string
+
+const (
+	// DeadlineEnd ends a session that is still running, or saves the results
+	// of a finished one again when saving them failed
+	DeadlineEnd DeadlineKind = "end"
+	// DeadlineLobby starts a session whose lobby countdown ran out
+	DeadlineLobby DeadlineKind = "lobby"
+)
+
+// Deadline is when something is due for a session. A session has at most
+// one deadline of each kind.
+type Deadline struct {
+	Kind      DeadlineKind
+	SessionID string
+	At        time.Time
+}
+
 type SessionManager interface {
 	FindQuizSession(ctx context.Context, sessionID string) (*models.Session, error)
-	FindQuizSessionByQuizID(ctx context.Context, quizID string) (*models.Session, error)
+	// sessions of the quiz that have not finished, oldest first
+	FindActiveQuizSessions(ctx context.Context, quizID string) ([]*models.Session, error)
+	// assigns the session ID and a join code unique among live sessions,
+	// practice sessions get no code and are not listed as active
 	CreateQuizSession(ctx context.Context, session *models.Session) error
 
-	AddPlayerToQuizSession(ctx context.Context, sessionID string, player models.SessionPlayer) error
+	// returns nil when no live session has the code
+	FindQuizSessionByJoinCode(ctx context.Context, code string) (*models.Session, error)
+
+	// counts a join code lookup of the client and returns how many it made
+	// in the current window, shared by every server so guessing cannot be
+	// spread across them. Lookups that found a session are forgotten again.
+	CountJoinCodeAttempt(ctx context.Context, client string) (int64, error)
+	ForgetJoinCodeAttempt(ctx context.Context, client string) error
+
+	// adds the player unless the session already has maxPlayers players
+	// (zero is unlimited), in which case ErrSessionFull is returned
+	AddPlayerToQuizSession(ctx context.Context, sessionID string, player models.SessionPlayer, maxPlayers int) error
+	RemovePlayerFromQuizSession(ctx context.Context, sessionID, playerID, reason string) error
 
 	FindQuizPlayerSession(ctx context.Context, sessionID, playerID string) (*models.Session, error)
 
-	// should update the leaderboard
+	// should update the leaderboard. Nothing is stored and ErrAlreadyAnswered
+	// is returned when the player already answered one of the questions.
 	UpdateQuizPlayerScoreSession(ctx context.Context, sessionID, playerID string, score int, res models.Result) error
 
 	// sorted by score
 	FindLeaderboardQuizSession(ctx context.Context, quizSessionID string) ([]models.SessionPlayer, error)
+
+	// moves the session to the given phase only if it currently is in one of
+	// the from phases, reports whether the transition happened
+	TransitionQuizSessionPhase(ctx context.Context, sessionID string, to models.SessionPhase, reason string, from ...models.SessionPhase) (bool, error)
+
+	// starts the timer of the current question, or stops it with remaining
+	// seconds left when deadline is nil. Running timers are kept outside the
+	// session as well so any node can find them after a restart.
+	SetQuestionTimer(ctx context.Context, sessionID string, questionIndex int, deadline *time.Time, remaining float64) error
+	// every running question timer, soonest first
+	ListQuestionTimers(ctx context.Context) ([]QuestionTimer, error)
+	// removes the timer if it still has the given deadline and reports
+	// whether this caller removed it, so only one node closes the question
+	ClaimQuestionTimer(ctx context.Context, timer QuestionTimer) (bool, error)
+	// puts a claimed timer back unless the session has a timer again, so a
+	// question that failed to close is tried again
+	ReleaseQuestionTimer(ctx context.Context, timer QuestionTimer) error
+	// logs the misses of players who have not answered the question yet,
+	// and then closed, in one step. Returns the misses that were logged, a
+	// player whose answer landed first gets none.
+	CloseQuestion(ctx context.Context, sessionID string, misses []models.SessionEvent, closed models.SessionEvent) ([]models.SessionEvent, error)
+
+	// sets or moves the session's deadline of that kind. Deadlines are kept
+	// outside the session so any node can act on them after a restart.
+	SetDeadline(ctx context.Context, deadline Deadline) error
+	// sets the deadline unless the session already has one of that kind and
+	// reports whether it did, so only the first of concurrent callers does
+	AddDeadline(ctx context.Context, deadline Deadline) (bool, error)
+	// deadlines due at now, soonest first
+	DueDeadlines(ctx context.Context, now time.Time) ([]Deadline, error)
+	// removes the deadline if it is still at the given time and reports
+	// whether this caller removed it, so only one node acts on it
+	ClaimDeadline(ctx context.Context, deadline Deadline) (bool, error)
+	DeleteDeadline(ctx context.Context, kind DeadlineKind, sessionID string) error
+
+	// append-only log the session state is folded from, in order
+	AppendQuizSessionEvents(ctx context.Context, sessionID string, events ...models.SessionEvent) error
+	FindQuizSessionEvents(ctx context.Context, sessionID string) ([]models.SessionEvent, error)
 }
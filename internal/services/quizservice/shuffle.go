@@ -0,0 +1,66 @@
+package quizservice
+
+import (
+	"math/rand"
+	"slices"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+// newPlayerOrder draws the order a player is shown the session in from seed,
+// nil when the quiz shuffles nothing
+func newPlayerOrder(session *models.Session, seed int64) *models.PlayerOrder {
+	quiz := session.Quiz
+	if quiz == nil || (!quiz.ShuffleQuestions && !quiz.ShuffleOptions) {
+		return nil
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	order := &models.PlayerOrder{Seed: seed}
+
+	// Hosted sessions move through the questions together
+	if quiz.ShuffleQuestions && !session.Hosted() {
+		order.Questions = rng.Perm(len(session.Questions))
+	}
+
+	if quiz.ShuffleOptions {
+		order.Options = make(map[string][]int)
+		for _, q := range session.Questions {
+			switch kind := q.Kind(); {
+			case kind.HasOptions():
+				order.Options[q.ID] = rng.Perm(len(q.Options))
+			case kind == models.QuestionMatchPairs:
+				order.Options[q.ID] = rng.Perm(len(q.Pairs))
+			}
+		}
+	}
+
+	return order
+}
+
+// orderedQuestions returns the questions in the order the player sees them
+func orderedQuestions(questions []models.Question, order *models.PlayerOrder) []models.Question {
+	if order == nil || len(order.Questions) != len(questions) {
+		return questions
+	}
+
+	ordered := make([]models.Question, 0, len(questions))
+	for _, i := range order.Questions {
+		ordered = append(ordered, questions[i])
+	}
+	return ordered
+}
+
+// permute returns items in the order of perm, or as they are when perm does
+// not fit them, e.g. for a question that was not shuffled
+func permute(items []string, perm []int) []string {
+	if len(perm) != len(items) {
+		return slices.Clone(items)
+	}
+
+	permuted := make([]string, 0, len(items))
+	for _, i := range perm {
+		permuted = append(permuted, items[i])
+	}
+	return permuted
+}
@@ -0,0 +1,273 @@
+package quizservice
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice/sessions"
+)
+
+const (
+	// defaultTimeLimit is the seconds per question when neither the question
+	// nor the quiz sets a limit, the window scoring was designed around
+	defaultTimeLimit = 5
+
+	// answerGrace is how long after the deadline answers are still taken,
+	// so an answer sent in the last moment is not lost to latency
+	answerGrace = time.Second
+)
+
+// questionTimeLimit returns the seconds a player has for the question
+func questionTimeLimit(quiz *models.Quiz, question models.Question) float64 {
+	switch {
+	case question.TimeLimit > 0:
+		return float64(question.TimeLimit)
+	case quiz != nil && quiz.TimeLimit > 0:
+		return float64(quiz.TimeLimit)
+	}
+	return defaultTimeLimit
+}
+
+// openQuestion starts the timer of a hosted session's question with the
+// given seconds left
+func (s *QuizService) openQuestion(ctx context.Context, session *models.Session, index int, seconds float64) error {
+	deadline := time.Now().Add(time.Duration(seconds * float64(time.Second))).Truncate(time.Millisecond)
+
+	if err := s.sessionManager.SetQuestionTimer(ctx, session.ID, index, &deadline, 0); err != nil {
+		return err
+	}
+
+	question := session.Questions[index]
+	return s.hub.BroadcastToRoom(ctx, session.ID, models.WSMessage{
+		Type: "question_opened",
+		Data: map[string]interface{}{
+			"question_index": index,
+			"question_id":    question.ID,
+			"time_limit":     questionTimeLimit(session.Quiz, question),
+			"deadline":       deadline,
+		},
+	})
+}
+
+// stopQuestionTimer keeps the seconds left on the current question so a
+// resume continues from there
+func (s *QuizService) stopQuestionTimer(ctx context.Context, session *models.Session) error {
+	if session.QuestionDeadline == nil {
+		return nil
+	}
+
+	remaining := math.Max(0, time.Until(*session.QuestionDeadline).Seconds())
+	return s.sessionManager.SetQuestionTimer(ctx, session.ID, session.CurrentQuestion, nil, remaining)
+}
+
+// timeAnswer returns the seconds the player took for the question of resKey
+// and whether the server measured them. Hosted sessions time answers against
+// the question's deadline, other sessions from when the player opened the
+// question. An answer to a question that was never opened is timed at the
+// limit, so it gets no speed points. The client's clock is never trusted.
+func timeAnswer(session *models.Session, resKey string, limit float64) (float64, bool, error) {
+	if session.Hosted() {
+		if session.QuestionDeadline == nil {
+			return limit, false, nil
+		}
+
+		left := time.Until(*session.QuestionDeadline)
+		if left < -answerGrace {
+			return 0, false, ErrQuestionClosed
+		}
+		return math.Min(limit, math.Max(0, limit-left.Seconds())), true, nil
+	}
+
+	openedAt, ok := session.Opened[resKey]
+	if !ok {
+		return limit, false, nil
+	}
+
+	// The grace covers the way back from the player, not a slower answer
+	elapsed := time.Since(openedAt).Seconds()
+	if elapsed <= limit+answerGrace.Seconds() {
+		elapsed = math.Min(elapsed, limit)
+	}
+	return elapsed, true, nil
+}
+
+// OpenQuestion starts the clock on a question for a player of a session
+// that is not hosted, the answer is timed from the first time the player
+// opened it. Hosted sessions time every question with its deadline.
+// Players with an account open questions signed in with token.
+func (s *QuizService) OpenQuestion(ctx context.Context, sessionID, playerID, questionID, token string) error {
+	if _, err := s.actingPlayer(ctx, playerID, token); err != nil {
+		return err
+	}
+
+	session, err := s.sessionManager.FindQuizPlayerSession(ctx, sessionID, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil {
+		return ErrSessionNotFound
+	}
+
+	if session.Phase != models.SessionPhaseInProgress {
+		return phaseError(session.Phase)
+	}
+
+	if session.Hosted() {
+		return nil
+	}
+
+	if !slices.ContainsFunc(session.Questions, func(q models.Question) bool { return q.ID == questionID }) {
+		return ErrQuestionNotFound
+	}
+
+	resKey := models.ResultKey(questionID, playerID)
+	if _, answered := session.Result[resKey]; answered {
+		return ErrAlreadyAnswered
+	}
+	if _, opened := session.Opened[resKey]; opened {
+		return nil
+	}
+
+	err = s.sessionManager.AppendQuizSessionEvents(ctx, sessionID, models.SessionEvent{
+		Type:       models.SessionEventQuestionOpened,
+		PlayerID:   playerID,
+		QuestionID: questionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open question: %w", err)
+	}
+
+	return nil
+}
+
+// runQuestionTimers closes the questions whose time is up and sends every
+// other running timer a countdown tick. Called by the scheduler.
+func (s *QuizService) runQuestionTimers(ctx context.Context, now time.Time) {
+	timers, err := s.sessionManager.ListQuestionTimers(ctx)
+	if err != nil {
+		log.Printf("scheduler: %v", err)
+		return
+	}
+
+	for _, timer := range timers {
+		if now.Before(timer.Deadline.Add(answerGrace)) {
+			remaining := math.Max(0, math.Ceil(timer.Deadline.Sub(now).Seconds()))
+			// Only the node holding the room can deliver ticks
+			_ = s.hub.NotifyRoom(ctx, timer.SessionID, models.WSMessage{
+				Type: "question_tick",
+				Data: map[string]interface{}{"remaining": remaining},
+			})
+			continue
+		}
+
+		if err := s.closeQuestion(ctx, timer); err != nil {
+			log.Printf("scheduler: session %s: %v", timer.SessionID, err)
+		}
+	}
+}
+
+// closeQuestion ends the current question once its time is up: players who
+// did not answer get a zero point miss and the session moves on. A question
+// that fails to close keeps its timer and is tried again on the next tick.
+func (s *QuizService) closeQuestion(ctx context.Context, timer sessions.QuestionTimer) error {
+	claimed, err := s.sessionManager.ClaimQuestionTimer(ctx, timer)
+	if err != nil || !claimed {
+		return err
+	}
+
+	session, misses, err := s.logMisses(ctx, timer)
+	if err != nil {
+		if releaseErr := s.sessionManager.ReleaseQuestionTimer(ctx, timer); releaseErr != nil {
+			log.Printf("failed to release question timer: %v", releaseErr)
+		}
+		return err
+	}
+
+	if session == nil {
+		return nil
+	}
+
+	index := session.CurrentQuestion
+	question := session.Questions[index]
+	limit := questionTimeLimit(session.Quiz, question)
+
+	// Not answering in time counts as forgetting the word
+	missed := make([]string, 0, len(misses))
+	for _, event := range misses {
+		s.recordReview(ctx, event.PlayerID, question, *event.Answer, limit)
+		s.recordRating(ctx, session, event.PlayerID, question, *event.Answer)
+		missed = append(missed, event.PlayerID)
+	}
+
+	err = s.hub.BroadcastToRoom(ctx, session.ID, models.WSMessage{
+		Type: "question_closed",
+		Data: map[string]interface{}{
+			"question_index": index,
+			"question_id":    question.ID,
+			"correct_answer": question.ExpectedAnswer(),
+			"missed":         missed,
+		},
+	})
+	if err != nil {
+		log.Printf("failed to broadcast question closed: %v", err)
+	}
+
+	return s.advanceQuestion(ctx, session.ID, index, false)
+}
+
+// logMisses closes the current question of the timer's session and returns
+// the misses that were logged. The session is nil when it is gone or no
+// longer running.
+func (s *QuizService) logMisses(ctx context.Context, timer sessions.QuestionTimer) (*models.Session, []models.SessionEvent, error) {
+	session, err := s.sessionManager.FindQuizSession(ctx, timer.SessionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil || session.Phase != models.SessionPhaseInProgress {
+		return nil, nil, nil
+	}
+
+	index := session.CurrentQuestion
+	question := session.Questions[index]
+	now := time.Now()
+
+	limit := questionTimeLimit(session.Quiz, question)
+
+	// Answers that land after this snapshot are kept out by the store
+	misses := make([]models.SessionEvent, 0, len(session.Players))
+	for _, player := range session.Players {
+		if _, ok := session.Result[models.ResultKey(question.ID, player.ID)]; ok {
+			continue
+		}
+
+		misses = append(misses, models.SessionEvent{
+			Type:       models.SessionEventAnswerSubmitted,
+			PlayerID:   player.ID,
+			QuestionID: question.ID,
+			Answer: &models.Answer{
+				CorrectAnswer: question.ExpectedAnswer(),
+				AnswerTime:    limit,
+				AnsweredAt:    now,
+				TimedOut:      true,
+				Timed:         true,
+			},
+		})
+	}
+
+	misses, err = s.sessionManager.CloseQuestion(ctx, session.ID, misses, models.SessionEvent{
+		Type:          models.SessionEventQuestionClosed,
+		QuestionIndex: index,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return session, misses, nil
+}
@@ -0,0 +1,317 @@
+package quizservice
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+// UpdateQuiz writes a new version of a quiz as the editor signed in with
+// token. Sessions already running keep the questions they were started with.
+func (s *QuizService) UpdateQuiz(ctx context.Context, quizID, token string, req UpdateQuizRequest) (*models.QuizVersion, error) {
+	if _, err := s.editingAccount(ctx, token); err != nil {
+		return nil, err
+	}
+
+	quiz, _, err := s.quizReader.GetQuiz(ctx, quizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quiz: %w", err)
+	}
+
+	if title := strings.TrimSpace(req.Title); title != "" {
+		quiz.Title = title
+	}
+
+	if req.TimeLimit != nil {
+		quiz.TimeLimit = *req.TimeLimit
+	}
+
+	if req.Scoring != nil {
+		quiz.Scoring = req.Scoring
+	}
+
+	if req.ShuffleQuestions != nil {
+		quiz.ShuffleQuestions = *req.ShuffleQuestions
+	}
+
+	if req.ShuffleOptions != nil {
+		quiz.ShuffleOptions = *req.ShuffleOptions
+	}
+
+	if req.Pool != nil {
+		quiz.Pool = req.Pool
+		if req.Pool.Draw == 0 {
+			quiz.Pool = nil
+		}
+	}
+
+	var questions []models.Question
+	if req.Questions != nil {
+		questions = make([]models.Question, 0, len(req.Questions))
+		for _, q := range req.Questions {
+			if q.ID == "" {
+				q.ID = uuid.New().String()
+			}
+			q.QuizID = quizID
+			questions = append(questions, q)
+		}
+
+		if err := s.resolveWords(ctx, questions); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.quizWriter.UpdateQuiz(ctx, quiz, questions); err != nil {
+		return nil, fmt.Errorf("failed to update quiz: %w", err)
+	}
+
+	return s.quizVersion(ctx, quizID, quiz.Version)
+}
+
+// Quiz returns the latest version of a quiz. The answer key is only included
+// for an editor's token.
+func (s *QuizService) Quiz(ctx context.Context, quizID, token string) (*models.QuizVersion, error) {
+	answerKey, err := s.answerKeyAccess(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.latestVersion(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !answerKey {
+		version = withoutVersionAnswerKey(version)
+	}
+
+	return version, nil
+}
+
+// QuizVersions returns the history of a quiz, oldest first. The answer key
+// is only included for an editor's token.
+func (s *QuizService) QuizVersions(ctx context.Context, quizID, token string) ([]models.QuizVersion, error) {
+	answerKey, err := s.answerKeyAccess(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.quizReader.ListQuizVersions(ctx, quizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quiz versions: %w", err)
+	}
+
+	if !answerKey {
+		for i := range versions {
+			versions[i] = *withoutVersionAnswerKey(&versions[i])
+		}
+	}
+
+	return versions, nil
+}
+
+// QuizVersion returns a single version of a quiz. The answer key is only
+// included for an editor's token.
+func (s *QuizService) QuizVersion(ctx context.Context, quizID string, version int, token string) (*models.QuizVersion, error) {
+	answerKey, err := s.answerKeyAccess(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := s.quizVersion(ctx, quizID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if !answerKey {
+		v = withoutVersionAnswerKey(v)
+	}
+
+	return v, nil
+}
+
+func (s *QuizService) latestVersion(ctx context.Context, quizID string) (*models.QuizVersion, error) {
+	quiz, _, err := s.quizReader.GetQuiz(ctx, quizID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quiz: %w", err)
+	}
+
+	return s.quizVersion(ctx, quizID, quiz.Version)
+}
+
+func (s *QuizService) quizVersion(ctx context.Context, quizID string, version int) (*models.QuizVersion, error) {
+	v, err := s.quizReader.GetQuizVersion(ctx, quizID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quiz version: %w", err)
+	}
+
+	return v, nil
+}
+
+// answerKeyAccess reports whether the answer key of quizzes may be read.
+// Anyone can read quizzes without it, a token given must be an editor's.
+func (s *QuizService) answerKeyAccess(ctx context.Context, token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	if _, err := s.editingAccount(ctx, token); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// withoutVersionAnswerKey copies a version with the answer key taken out of
+// its questions
+func withoutVersionAnswerKey(version *models.QuizVersion) *models.QuizVersion {
+	redacted := *version
+	redacted.Questions = withoutAnswerKey(version.Questions)
+	return &redacted
+}
+
+// RollbackQuiz restores an older version by writing its content as a new
+// version, so the history itself is never rewritten. The rollback is made
+// by the editor signed in with token.
+func (s *QuizService) RollbackQuiz(ctx context.Context, quizID, token string, version int) (*models.QuizVersion, error) {
+	if _, err := s.editingAccount(ctx, token); err != nil {
+		return nil, err
+	}
+
+	restored, err := s.quizWriter.RollbackQuiz(ctx, quizID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll back quiz: %w", err)
+	}
+
+	return restored, nil
+}
+
+// DiffQuizVersions compares two versions of a quiz for the editor signed in
+// with token, answers included
+func (s *QuizService) DiffQuizVersions(ctx context.Context, quizID, token string, from, to int) (*QuizDiff, error) {
+	if _, err := s.editingAccount(ctx, token); err != nil {
+		return nil, err
+	}
+
+	a, err := s.quizVersion(ctx, quizID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.quizVersion(ctx, quizID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &QuizDiff{
+		QuizID: quizID,
+		From:   from,
+		To:     to,
+	}
+
+	diff.Fields = diffQuiz(a.Quiz, b.Quiz)
+
+	before := make(map[string]models.Question, len(a.Questions))
+	for _, q := range a.Questions {
+		before[q.ID] = q
+	}
+
+	after := make(map[string]bool, len(b.Questions))
+	for _, q := range b.Questions {
+		after[q.ID] = true
+
+		old, ok := before[q.ID]
+		if !ok {
+			diff.Added = append(diff.Added, q)
+			continue
+		}
+
+		if fields := diffQuestion(old, q); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, QuestionChange{QuestionID: q.ID, Fields: fields})
+		}
+	}
+
+	for _, q := range a.Questions {
+		if !after[q.ID] {
+			diff.Removed = append(diff.Removed, q)
+		}
+	}
+
+	return diff, nil
+}
+
+func diffQuiz(a, b models.Quiz) []FieldChange {
+	var fields []FieldChange
+	if a.Title != b.Title {
+		fields = append(fields, FieldChange{Field: "title", From: a.Title, To: b.Title})
+	}
+	if a.Status != b.Status {
+		fields = append(fields, FieldChange{Field: "status", From: a.Status, To: b.Status})
+	}
+	if a.TimeLimit != b.TimeLimit {
+		fields = append(fields, FieldChange{Field: "time_limit", From: a.TimeLimit, To: b.TimeLimit})
+	}
+	if !reflect.DeepEqual(a.Scoring, b.Scoring) {
+		fields = append(fields, FieldChange{Field: "scoring", From: a.Scoring, To: b.Scoring})
+	}
+	if a.ShuffleQuestions != b.ShuffleQuestions {
+		fields = append(fields, FieldChange{Field: "shuffle_questions", From: a.ShuffleQuestions, To: b.ShuffleQuestions})
+	}
+	if a.ShuffleOptions != b.ShuffleOptions {
+		fields = append(fields, FieldChange{Field: "shuffle_options", From: a.ShuffleOptions, To: b.ShuffleOptions})
+	}
+	if !reflect.DeepEqual(a.Pool, b.Pool) {
+		fields = append(fields, FieldChange{Field: "pool", From: a.Pool, To: b.Pool})
+	}
+	return fields
+}
+
+func diffQuestion(a, b models.Question) []FieldChange {
+	var fields []FieldChange
+	if a.Kind() != b.Kind() {
+		fields = append(fields, FieldChange{Field: "type", From: a.Kind(), To: b.Kind()})
+	}
+	if a.WordID != b.WordID {
+		fields = append(fields, FieldChange{Field: "word_id", From: a.WordID, To: b.WordID})
+	}
+	if a.Word != b.Word {
+		fields = append(fields, FieldChange{Field: "word", From: a.Word, To: b.Word})
+	}
+	if a.Meaning != b.Meaning {
+		fields = append(fields, FieldChange{Field: "meaning", From: a.Meaning, To: b.Meaning})
+	}
+	if !slices.Equal(a.Options, b.Options) {
+		fields = append(fields, FieldChange{Field: "options", From: a.Options, To: b.Options})
+	}
+	if a.Correct != b.Correct {
+		fields = append(fields, FieldChange{Field: "correct", From: a.Correct, To: b.Correct})
+	}
+	if a.Sentence != b.Sentence {
+		fields = append(fields, FieldChange{Field: "sentence", From: a.Sentence, To: b.Sentence})
+	}
+	if !slices.Equal(a.Pairs, b.Pairs) {
+		fields = append(fields, FieldChange{Field: "pairs", From: a.Pairs, To: b.Pairs})
+	}
+	if !reflect.DeepEqual(a.Grading, b.Grading) {
+		fields = append(fields, FieldChange{Field: "grading", From: a.Grading, To: b.Grading})
+	}
+	if a.Difficulty != b.Difficulty {
+		fields = append(fields, FieldChange{Field: "difficulty", From: a.Difficulty, To: b.Difficulty})
+	}
+	if !slices.Equal(a.Tags, b.Tags) {
+		fields = append(fields, FieldChange{Field: "tags", From: a.Tags, To: b.Tags})
+	}
+	if a.TimeLimit != b.TimeLimit {
+		fields = append(fields, FieldChange{Field: "time_limit", From: a.TimeLimit, To: b.TimeLimit})
+	}
+	if a.Explanation != b.Explanation {
+		fields = append(fields, FieldChange{Field: "explanation", From: a.Explanation, To: b.Explanation})
+	}
+	return fields
+}
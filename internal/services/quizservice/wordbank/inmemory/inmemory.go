@@ -0,0 +1,170 @@
+package inmemorywordbank
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice/wordbank"
+)
+
+type WordBank struct {
+	mu    sync.RWMutex
+	words map[string]models.Word
+}
+
+var _ wordbank.WordBank = (*WordBank)(nil)
+
+func NewWordBank() *WordBank {
+	bank := &WordBank{
+		words: make(map[string]models.Word),
+	}
+
+	// Initialize with sample data
+	bank.initSampleData()
+	return bank
+}
+
+func (b *WordBank) FindWord(ctx context.Context, id string) (*models.Word, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	word, ok := b.words[id]
+	if !ok {
+		return nil, nil
+	}
+
+	word = cloneWord(word)
+	return &word, nil
+}
+
+func (b *WordBank) ListWords(ctx context.Context, filter wordbank.WordFilter) ([]*models.Word, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	query := strings.ToLower(filter.Query)
+
+	words := make([]*models.Word, 0, len(b.words))
+	for _, word := range b.words {
+		switch {
+		case query != "" && !strings.HasPrefix(strings.ToLower(word.Lemma), query),
+			filter.PartOfSpeech != "" && word.PartOfSpeech != filter.PartOfSpeech,
+			filter.Level != "" && word.Level != filter.Level,
+			filter.Tag != "" && !slices.Contains(word.Tags, filter.Tag):
+			continue
+		}
+
+		word = cloneWord(word)
+		words = append(words, &word)
+	}
+
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Lemma != words[j].Lemma {
+			return words[i].Lemma < words[j].Lemma
+		}
+		return words[i].PartOfSpeech < words[j].PartOfSpeech
+	})
+
+	return words, nil
+}
+
+func (b *WordBank) SaveWord(ctx context.Context, word *models.Word) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, existing := range b.words {
+		if id != word.ID &&
+			strings.EqualFold(existing.Lemma, word.Lemma) &&
+			existing.PartOfSpeech == word.PartOfSpeech {
+			return fmt.Errorf("%w: %s (%s)", wordbank.ErrWordExists, word.Lemma, id)
+		}
+	}
+
+	b.words[word.ID] = cloneWord(*word)
+	return nil
+}
+
+func (b *WordBank) DeleteWord(ctx context.Context, id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.words, id)
+	return nil
+}
+
+// cloneWord copies the slices so stored words can't be changed through a
+// returned value
+func cloneWord(w models.Word) models.Word {
+	w.Definitions = slices.Clone(w.Definitions)
+	w.Examples = slices.Clone(w.Examples)
+	w.Synonyms = slices.Clone(w.Synonyms)
+	w.Tags = slices.Clone(w.Tags)
+	return w
+}
+
+// Sample data initialization, the words of the sample quizzes
+func (b *WordBank) initSampleData() {
+	now := time.Now()
+	sampleWords := []models.Word{
+		{
+			ID:           "w_hibernate",
+			Lemma:        "hibernate",
+			PartOfSpeech: "verb",
+			Definitions:  []string{"To spend the winter in a dormant state"},
+			Examples:     []string{"Bears hibernate through the winter in their dens"},
+			Synonyms:     []string{"overwinter", "lie dormant"},
+			Level:        models.CEFRB2,
+			Tags:         []string{"animals", "seasons"},
+		},
+		{
+			ID:           "w_camouflage",
+			Lemma:        "camouflage",
+			PartOfSpeech: "noun",
+			Definitions:  []string{"Colouring that lets an animal blend into its surroundings"},
+			Examples:     []string{"The moth's camouflage makes it hard to spot on tree bark"},
+			Synonyms:     []string{"disguise", "concealment"},
+			Level:        models.CEFRB2,
+			Tags:         []string{"animals"},
+		},
+		{
+			ID:           "w_nocturnal",
+			Lemma:        "nocturnal",
+			PartOfSpeech: "adjective",
+			Definitions:  []string{"Active at night"},
+			Examples:     []string{"Owls are nocturnal hunters"},
+			Level:        models.CEFRB1,
+			Tags:         []string{"animals"},
+		},
+		{
+			ID:           "w_ferocious",
+			Lemma:        "ferocious",
+			PartOfSpeech: "adjective",
+			Definitions:  []string{"Savagely fierce or violent"},
+			Examples:     []string{"A ferocious storm swept the coast"},
+			Synonyms:     []string{"savage", "fierce", "brutal"},
+			Level:        models.CEFRB2,
+			Tags:         []string{"animals", "describing"},
+		},
+		{
+			ID:           "w_predator",
+			Lemma:        "predator",
+			PartOfSpeech: "noun",
+			Definitions:  []string{"An animal that hunts other animals for food"},
+			Examples:     []string{"The lion is the top predator of the savanna"},
+			Synonyms:     []string{"hunter"},
+			Level:        models.CEFRB1,
+			Tags:         []string{"animals"},
+		},
+	}
+
+	for _, word := range sampleWords {
+		word.CreatedAt = now
+		word.UpdatedAt = now
+		b.words[word.ID] = word
+	}
+}
@@ -0,0 +1,30 @@
+package wordbank
+
+import (
+	"context"
+	"errors"
+
+	"wordwizardry/internal/pkg/models"
+)
+
+var ErrWordExists = errors.New("word already exists")
+
+// WordFilter narrows a word listing, empty fields match every word
+type WordFilter struct {
+	Query        string // lemma prefix, case insensitive
+	PartOfSpeech string
+	Level        models.CEFRLevel
+	Tag          string
+}
+
+// WordBank keeps the vocabulary questions are written from
+type WordBank interface {
+	// returns nil when there is no such word
+	FindWord(ctx context.Context, id string) (*models.Word, error)
+	// sorted by lemma
+	ListWords(ctx context.Context, filter WordFilter) ([]*models.Word, error)
+	// creates or replaces the word, ErrWordExists when another word has the
+	// same lemma and part of speech
+	SaveWord(ctx context.Context, word *models.Word) error
+	DeleteWord(ctx context.Context, id string) error
+}
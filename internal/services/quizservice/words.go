@@ -0,0 +1,262 @@
+package quizservice
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/pkg/quizgen"
+	"wordwizardry/internal/services/quizservice/wordbank"
+)
+
+// CreateWord adds a word to the word bank
+func (s *QuizService) CreateWord(ctx context.Context, req WordRequest) (*models.Word, error) {
+	word, err := req.word()
+	if err != nil {
+		return nil, err
+	}
+
+	word.ID = uuid.New().String()
+	word.CreatedAt = time.Now()
+	word.UpdatedAt = word.CreatedAt
+
+	if err := s.wordBank.SaveWord(ctx, word); err != nil {
+		return nil, fmt.Errorf("failed to save word: %w", err)
+	}
+
+	return word, nil
+}
+
+// UpdateWord replaces a word. Questions already written from it keep their
+// text, the word ID keeps them counted in its stats.
+func (s *QuizService) UpdateWord(ctx context.Context, id string, req WordRequest) (*models.Word, error) {
+	existing, err := s.Word(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	word, err := req.word()
+	if err != nil {
+		return nil, err
+	}
+
+	word.ID = existing.ID
+	word.CreatedAt = existing.CreatedAt
+	word.UpdatedAt = time.Now()
+
+	if err := s.wordBank.SaveWord(ctx, word); err != nil {
+		return nil, fmt.Errorf("failed to save word: %w", err)
+	}
+
+	return word, nil
+}
+
+func (s *QuizService) Word(ctx context.Context, id string) (*models.Word, error) {
+	word, err := s.wordBank.FindWord(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find word: %w", err)
+	}
+
+	if word == nil {
+		return nil, fmt.Errorf("%w: %s", ErrWordNotFound, id)
+	}
+
+	return word, nil
+}
+
+func (s *QuizService) Words(ctx context.Context, filter wordbank.WordFilter) ([]*models.Word, error) {
+	if filter.Level != "" && !filter.Level.IsValid() {
+		return nil, fmt.Errorf("%w: unknown CEFR level %q", ErrInvalidWord, filter.Level)
+	}
+
+	words, err := s.wordBank.ListWords(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list words: %w", err)
+	}
+
+	return words, nil
+}
+
+// DeleteWord removes a word no version of any quiz asks about. Older
+// versions count too, they can be published again or rolled back to.
+func (s *QuizService) DeleteWord(ctx context.Context, id string) error {
+	if _, err := s.Word(ctx, id); err != nil {
+		return err
+	}
+
+	quizzes, err := s.quizReader.ListQuizzes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list quizzes: %w", err)
+	}
+
+	for _, latest := range quizzes {
+		versions, err := s.quizReader.ListQuizVersions(ctx, latest.Quiz.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list quiz versions: %w", err)
+		}
+
+		for _, version := range versions {
+			if slices.ContainsFunc(version.Questions, func(q models.Question) bool { return q.WordID == id }) {
+				return fmt.Errorf("%w: asked by quiz %s version %d", ErrWordInUse, version.Quiz.ID, version.Quiz.Version)
+			}
+		}
+	}
+
+	if err := s.wordBank.DeleteWord(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete word: %w", err)
+	}
+
+	return nil
+}
+
+// WordStats adds up how players did on the questions asking about a word,
+// across every quiz and version
+func (s *QuizService) WordStats(ctx context.Context, id string) (*WordStats, error) {
+	word, err := s.Word(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	quizzes, err := s.quizReader.ListQuizzes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quizzes: %w", err)
+	}
+
+	stats := &WordStats{WordID: word.ID, Lemma: word.Lemma}
+	for _, latest := range quizzes {
+		if slices.ContainsFunc(latest.Questions, func(q models.Question) bool { return q.WordID == id }) {
+			stats.Quizzes = append(stats.Quizzes, latest.Quiz.ID)
+		}
+
+		if err := s.addWordResults(ctx, stats, latest.Quiz.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if stats.Answers > 0 {
+		stats.Accuracy = float64(stats.Correct) / float64(stats.Answers)
+		stats.AverageTime /= float64(stats.Answers)
+	}
+
+	return stats, nil
+}
+
+// addWordResults counts the answers saved with a quiz's results that were
+// given to questions asking about the word
+func (s *QuizService) addWordResults(ctx context.Context, stats *WordStats, quizID string) error {
+	results, err := s.quizReader.GetQuizResults(ctx, quizID)
+	if err != nil {
+		return fmt.Errorf("failed to get quiz results: %w", err)
+	}
+
+	// Results of one version share its questions
+	asked := make(map[int]map[string]bool)
+	for _, result := range results {
+		questions, ok := asked[result.QuizVersion]
+		if !ok {
+			version, err := s.quizReader.GetQuizVersion(ctx, quizID, result.QuizVersion)
+			if err != nil {
+				return fmt.Errorf("failed to get quiz version: %w", err)
+			}
+
+			questions = make(map[string]bool)
+			for _, q := range version.Questions {
+				if q.WordID == stats.WordID {
+					questions[q.ID] = true
+				}
+			}
+			asked[result.QuizVersion] = questions
+		}
+
+		for _, answer := range result.Answers {
+			if !questions[answer.QuestionID] || answer.AnsweredAt.IsZero() {
+				continue
+			}
+
+			stats.Answers++
+			stats.AverageTime += answer.AnswerTime
+			if answer.Correct {
+				stats.Correct++
+			}
+			if answer.TimedOut {
+				stats.TimedOut++
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveWords fills in the text of questions written from the word bank.
+// Text the editor wrote stays as it is.
+func (s *QuizService) resolveWords(ctx context.Context, questions []models.Question) error {
+	for i := range questions {
+		if err := s.resolveWord(ctx, &questions[i]); err != nil {
+			return fmt.Errorf("question %s: %w", questions[i].ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *QuizService) resolveWord(ctx context.Context, q *models.Question) error {
+	if q.WordID == "" {
+		return nil
+	}
+
+	word, err := s.Word(ctx, q.WordID)
+	if err != nil {
+		return err
+	}
+
+	if q.Word == "" {
+		q.Word = word.Lemma
+	}
+	if q.Meaning == "" && len(word.Definitions) > 0 {
+		q.Meaning = word.Definitions[0]
+	}
+	if q.Kind() == models.QuestionFillBlank && q.Sentence == "" {
+		q.Sentence = quizgen.BlankExample(word)
+	}
+
+	return nil
+}
+
+func (req WordRequest) word() (*models.Word, error) {
+	word := &models.Word{
+		Lemma:        strings.TrimSpace(req.Lemma),
+		PartOfSpeech: strings.ToLower(strings.TrimSpace(req.PartOfSpeech)),
+		Definitions:  cleanList(req.Definitions),
+		Examples:     cleanList(req.Examples),
+		Synonyms:     cleanList(req.Synonyms),
+		Level:        models.CEFRLevel(strings.ToUpper(string(req.Level))),
+		Tags:         cleanList(req.Tags),
+	}
+
+	switch {
+	case word.Lemma == "":
+		return nil, fmt.Errorf("%w: lemma is required", ErrInvalidWord)
+	case len(word.Definitions) == 0:
+		return nil, fmt.Errorf("%w: at least one definition is required", ErrInvalidWord)
+	case word.Level != "" && !word.Level.IsValid():
+		return nil, fmt.Errorf("%w: unknown CEFR level %q", ErrInvalidWord, req.Level)
+	}
+
+	return word, nil
+}
+
+// cleanList trims the entries of a list and drops the empty ones
+func cleanList(list []string) []string {
+	cleaned := make([]string, 0, len(list))
+	for _, item := range list {
+		if item = strings.TrimSpace(item); item != "" {
+			cleaned = append(cleaned, item)
+		}
+	}
+	return cleaned
+}
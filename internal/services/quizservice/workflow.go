@@ -0,0 +1,141 @@
+package quizservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/pkg/quizlint"
+)
+
+// ChangeQuizStatus moves a quiz version through the editorial workflow:
+// draft -> in_review -> published -> archived, with in_review going back to
+// draft when rejected and archived versions reopening as drafts. The change
+// is made by the editor signed in with token. Versions are only
+// published through ReviewQuiz.
+func (s *QuizService) ChangeQuizStatus(ctx context.Context, quizID, token string, req ChangeQuizStatusRequest) (*models.QuizVersion, error) {
+	editor, err := s.editingAccount(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Status == models.QuizStatusPublished {
+		return nil, fmt.Errorf("%w: versions are published by approving their review", ErrInvalidTransition)
+	}
+
+	return s.changeQuizStatus(ctx, quizID, editor.Username, req)
+}
+
+// changeQuizStatus moves a version to req.Status as actor. Publishing
+// requires a reviewer other than the editor who submitted the version.
+func (s *QuizService) changeQuizStatus(ctx context.Context, quizID, actor string, req ChangeQuizStatusRequest) (*models.QuizVersion, error) {
+	if !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidTransition, req.Status)
+	}
+
+	version, err := s.workflowVersion(ctx, quizID, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	from := version.Quiz.Status
+	if !from.CanTransitionTo(req.Status) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, req.Status)
+	}
+
+	// Content written under older rules, e.g. restored by a rollback, is
+	// checked again before anyone is asked to review or play it
+	if req.Status == models.QuizStatusInReview || req.Status == models.QuizStatusPublished {
+		if err := quizlint.Validate(&version.Quiz, version.Questions); err != nil {
+			return nil, err
+		}
+	}
+
+	if from == models.QuizStatusInReview && req.Status == models.QuizStatusPublished {
+		if submitter := submittedBy(version); submitter == actor {
+			return nil, ErrSelfReview
+		}
+	}
+
+	err = s.quizWriter.ChangeQuizStatus(ctx, quizID, version.Quiz.Version, models.QuizStatusChange{
+		From:    from,
+		To:      req.Status,
+		Actor:   actor,
+		Comment: req.Comment,
+		At:      time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to change quiz status: %w", err)
+	}
+
+	return s.quizVersion(ctx, quizID, version.Quiz.Version)
+}
+
+// ReviewQuiz approves (publishes) or rejects (back to draft) a version that
+// is in review. The reviewer is the editor signed in with token.
+func (s *QuizService) ReviewQuiz(ctx context.Context, quizID, token string, req ReviewQuizRequest) (*models.QuizVersion, error) {
+	reviewer, err := s.editingAccount(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.workflowVersion(ctx, quizID, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if version.Quiz.Status != models.QuizStatusInReview {
+		return nil, fmt.Errorf("%w: version %d is %s, not in review", ErrInvalidTransition, version.Quiz.Version, version.Quiz.Status)
+	}
+
+	status := models.QuizStatusDraft
+	if req.Approve {
+		status = models.QuizStatusPublished
+	}
+
+	return s.changeQuizStatus(ctx, quizID, reviewer.Username, ChangeQuizStatusRequest{
+		Version: version.Quiz.Version,
+		Status:  status,
+		Comment: req.Comment,
+	})
+}
+
+// editingAccount is who writes quizzes and changes their workflow status,
+// one of the editors set with SetEditors. Guests can rename themselves when
+// they register, so they never are, which keeps the submitter of a version
+// recognisable.
+func (s *QuizService) editingAccount(ctx context.Context, token string) (*models.Account, error) {
+	account, err := s.signedInAccount(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Guest {
+		return nil, ErrGuestAccount
+	}
+
+	if !s.editors[account.ID] {
+		return nil, ErrNotEditor
+	}
+
+	return account, nil
+}
+
+// workflowVersion loads the given version, or the latest one when zero
+func (s *QuizService) workflowVersion(ctx context.Context, quizID string, version int) (*models.QuizVersion, error) {
+	if version == 0 {
+		return s.latestVersion(ctx, quizID)
+	}
+	return s.quizVersion(ctx, quizID, version)
+}
+
+// submittedBy returns who last sent the version to review
+func submittedBy(version *models.QuizVersion) string {
+	for i := len(version.StatusHistory) - 1; i >= 0; i-- {
+		if change := version.StatusHistory[i]; change.To == models.QuizStatusInReview {
+			return change.Actor
+		}
+	}
+	return ""
+}
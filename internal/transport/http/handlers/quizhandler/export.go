@@ -0,0 +1,56 @@
+package quizhandler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"wordwizardry/internal/pkg/export"
+)
+
+// ExportSession streams the answers of a session as csv, ndjson or xlsx
+// Running sessions are only exported to their host, with the X-Host-Key header
+func (h *QuizHandler) ExportSession(w http.ResponseWriter, r *http.Request) {
+	format, err := export.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sessionID := r.PathValue("id")
+	rows, err := h.quizService.SessionExportRows(r.Context(), sessionID, r.Header.Get(hostKeyHeader))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeExport(w, format, "session-"+sessionID, rows)
+}
+
+// ExportQuiz streams the saved answers of every finished session of a quiz
+func (h *QuizHandler) ExportQuiz(w http.ResponseWriter, r *http.Request) {
+	format, err := export.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	quizID := r.PathValue("id")
+	rows, err := h.quizService.QuizExportRows(r.Context(), quizID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeExport(w, format, "quiz-"+quizID, rows)
+}
+
+func writeExport(w http.ResponseWriter, format export.Format, name string, rows []export.Row) {
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"."+format.Extension()))
+
+	// Headers are already sent, a failure here can only be logged
+	if err := export.WriteRows(w, format, rows); err != nil {
+		log.Printf("failed to write export: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"wordwizardry/internal/services/quizservice"
+)
+
+// GenerateQuiz creates a quiz from the word bank
+func (h *QuizHandler) GenerateQuiz(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.GenerateQuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.quizService.GenerateQuiz(r.Context(), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !req.DryRun {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
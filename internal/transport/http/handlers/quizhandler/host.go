@@ -0,0 +1,116 @@
+package quizhandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice"
+)
+
+// hostKeyHeader carries the host key returned when the session was created
+const hostKeyHeader = "X-Host-Key"
+
+// clientMessageTimeout bounds the work done for one WebSocket message
+const clientMessageTimeout = 10 * time.Second
+
+// HostCommand runs the host action in the path, e.g. start, skip or kick.
+// kick, mute and unmute take {"player_id": "..."} as body.
+func (h *QuizHandler) HostCommand(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+
+	if _, err := h.quizService.AuthorizeHost(r.Context(), sessionID, r.Header.Get(hostKeyHeader)); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	var cmd quizservice.HostCommand
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	// The path decides the action, the body only carries its target
+	cmd.Action = quizservice.HostAction(r.PathValue("action"))
+
+	if err := h.quizService.RunHostCommand(r.Context(), sessionID, cmd); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type clientMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// HandleClientMessage routes what clients send over the WebSocket. The host
+// connection was authorized when it connected, so its commands are trusted.
+// Failures are reported back to the sender only.
+func (h *QuizHandler) HandleClientMessage(sessionID, playerID string, payload []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), clientMessageTimeout)
+	defer cancel()
+
+	var msg clientMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		h.sendClientError(ctx, sessionID, playerID, "invalid message")
+		return
+	}
+
+	var err error
+	switch msg.Type {
+	case "host_command":
+		if playerID != quizservice.HostClientID {
+			err = quizservice.ErrNotHost
+			break
+		}
+
+		var cmd quizservice.HostCommand
+		if err = json.Unmarshal(msg.Data, &cmd); err != nil {
+			break
+		}
+		err = h.quizService.RunHostCommand(ctx, sessionID, cmd)
+
+	case "ready":
+		var ready struct {
+			Ready bool   `json:"ready"`
+			Token string `json:"token"` // players with an account, see playerTokenHeader
+		}
+		if err = json.Unmarshal(msg.Data, &ready); err != nil {
+			break
+		}
+		err = h.quizService.SetReady(ctx, sessionID, playerID, ready.Token, ready.Ready)
+
+	case "chat":
+		var chat struct {
+			Text string `json:"text"`
+		}
+		if err = json.Unmarshal(msg.Data, &chat); err != nil {
+			break
+		}
+		err = h.quizService.SendChat(ctx, sessionID, playerID, chat.Text)
+
+	default:
+		err = errors.New("unknown message type: " + msg.Type)
+	}
+
+	if err != nil {
+		h.sendClientError(ctx, sessionID, playerID, err.Error())
+	}
+}
+
+func (h *QuizHandler) sendClientError(ctx context.Context, sessionID, playerID, message string) {
+	err := h.hub.SendToPlayer(ctx, sessionID, playerID, models.WSMessage{
+		Type: "error",
+		Data: map[string]interface{}{"message": message},
+	})
+	if err != nil {
+		log.Printf("Failed to send error message: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"wordwizardry/internal/pkg/quizimport"
+	"wordwizardry/internal/services/quizservice"
+)
+
+// maxImportSize limits uploaded quiz files
+const maxImportSize = 5 << 20
+
+// ImportQuiz creates a quiz from an uploaded csv, json or anki file. The file
+// is either the raw request body or the "file" field of a multipart form.
+// Query parameters: format (inferred from the file name when uploading),
+// title, quiz_id and dry_run.
+func (h *QuizHandler) ImportQuiz(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	query := r.URL.Query()
+
+	var (
+		data     io.Reader = r.Body
+		filename string
+	)
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "Missing file", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		data = file
+		filename = header.Filename
+	}
+
+	var (
+		format quizimport.Format
+		err    error
+	)
+	if name := query.Get("format"); name != "" || filename == "" {
+		format, err = quizimport.ParseFormat(name)
+	} else {
+		format, err = quizimport.FormatFromFilename(filename)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(query.Get("dry_run"))
+
+	resp, err := h.quizService.ImportQuiz(r.Context(), quizservice.ImportQuizRequest{
+		Format: format,
+		QuizID: query.Get("quiz_id"),
+		Title:  query.Get("title"),
+		DryRun: dryRun,
+		Data:   data,
+	})
+	if err != nil {
+		var importErrs quizimport.Errors
+		if errors.As(err, &importErrs) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"errors": importErrs,
+			})
+			return
+		}
+
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !dryRun {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
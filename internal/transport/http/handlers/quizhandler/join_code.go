@@ -0,0 +1,46 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+
+	"wordwizardry/internal/services/quizservice"
+)
+
+// JoinByCode adds a player to the session with the join code in the path
+func (h *QuizHandler) JoinByCode(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.JoinByCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	req.Token = r.Header.Get(playerTokenHeader)
+
+	if req.Username == "" && req.Token == "" {
+		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		return
+	}
+
+	req.Client = clientAddr(r)
+
+	resp, err := h.quizService.JoinByCode(r.Context(), r.PathValue("code"), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// clientAddr identifies the client of a request for the attempt limits.
+// Forwarded headers are set by the client, only the connection address
+// cannot be chosen freely to dodge a limit.
+func clientAddr(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,45 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"wordwizardry/internal/services/quizservice"
+)
+
+// LintQuiz reports the lint issues of a stored quiz. The version query
+// parameter defaults to the latest version.
+func (h *QuizHandler) LintQuiz(w http.ResponseWriter, r *http.Request) {
+	version := 0
+	if v := r.URL.Query().Get("version"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "Invalid version", http.StatusBadRequest)
+			return
+		}
+		version = n
+	}
+
+	report, err := h.quizService.LintQuiz(r.Context(), r.PathValue("id"), version)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// LintQuizContent reports the lint issues of a quiz sent in the body
+// without saving it
+func (h *QuizHandler) LintQuizContent(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.LintQuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.quizService.LintQuizContent(req))
+}
@@ -0,0 +1,122 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"wordwizardry/internal/services/quizservice"
+)
+
+// playerTokenHeader carries the token a player got when signing in
+const playerTokenHeader = "X-Player-Token"
+
+// Register creates an account with a username and password and signs it in
+func (h *QuizHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.SignUpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.quizService.Register(r.Context(), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// CreateGuest creates a guest account from {"username": "..."}, it can be
+// upgraded with a password later
+func (h *QuizHandler) CreateGuest(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.SignUpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.quizService.CreateGuest(r.Context(), req.Username)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *QuizHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.SignUpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	req.Client = clientAddr(r)
+
+	resp, err := h.quizService.Login(r.Context(), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *QuizHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if err := h.quizService.Logout(r.Context(), r.Header.Get(playerTokenHeader)); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpgradeGuest sets the password of the signed in guest, and their username
+// when the body has one
+func (h *QuizHandler) UpgradeGuest(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.SignUpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	account, err := h.quizService.UpgradeGuest(r.Context(), r.Header.Get(playerTokenHeader), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(account)
+}
+
+// Me returns the account of the signed in player
+func (h *QuizHandler) Me(w http.ResponseWriter, r *http.Request) {
+	account, err := h.quizService.Me(r.Context(), r.Header.Get(playerTokenHeader))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(account)
+}
+
+// Profile returns the games, accuracy, best scores and recently missed
+// words of a registered player
+func (h *QuizHandler) Profile(w http.ResponseWriter, r *http.Request) {
+	profile, err := h.quizService.Profile(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
@@ -0,0 +1,49 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"wordwizardry/internal/services/quizservice"
+)
+
+// StartPractice starts a solo session that is not ranked with the others
+func (h *QuizHandler) StartPractice(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.PracticeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	req.Token = r.Header.Get(playerTokenHeader)
+
+	if req.QuizID == "" || (req.Username == "" && req.Token == "") {
+		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.quizService.StartPractice(r.Context(), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// PracticeResults returns the practice results of a quiz, filtered to one
+// player with ?username=
+func (h *QuizHandler) PracticeResults(w http.ResponseWriter, r *http.Request) {
+	results, err := h.quizService.PracticeResults(r.Context(), r.PathValue("id"), r.URL.Query().Get("username"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"results": results,
+	})
+}
@@ -2,8 +2,10 @@ package quizhandler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"wordwizardry/internal/pkg/quizlint"
 	"wordwizardry/internal/services/broadcast"
 	"wordwizardry/internal/services/quizservice"
 )
@@ -32,14 +34,16 @@ func (h *QuizHandler) JoinQuiz(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.QuizID == "" || req.Username == "" {
+	req.Token = r.Header.Get(playerTokenHeader)
+
+	if (req.QuizID == "" && req.SessionID == "") || (req.Username == "" && req.Token == "") {
 		http.Error(w, "Missing required fields", http.StatusBadRequest)
 		return
 	}
 
 	resp, err := h.quizService.JoinQuiz(r.Context(), req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, err)
 		return
 	}
 
@@ -64,10 +68,89 @@ func (h *QuizHandler) SubmitAnswer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.quizService.SubmitAnswer(r.Context(), req); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	req.Token = r.Header.Get(playerTokenHeader)
+
+	feedback, err := h.quizService.SubmitAnswer(r.Context(), req)
+	if err != nil {
+		writeServiceError(w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(feedback)
+}
+
+// writeServiceError maps service errors to HTTP status codes
+func writeServiceError(w http.ResponseWriter, err error) {
+	var lintErr *quizlint.ValidationError
+	if errors.As(err, &lintErr) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(lintErr.Report)
+		return
+	}
+
+	switch {
+	case errors.Is(err, quizservice.ErrSessionNotFound),
+		errors.Is(err, quizservice.ErrQuizNotFound),
+		errors.Is(err, quizservice.ErrVersionNotFound),
+		errors.Is(err, quizservice.ErrNotPublished),
+		errors.Is(err, quizservice.ErrScheduleNotFound),
+		errors.Is(err, quizservice.ErrJoinCodeNotFound),
+		errors.Is(err, quizservice.ErrPlayerNotFound),
+		errors.Is(err, quizservice.ErrQuestionNotFound),
+		errors.Is(err, quizservice.ErrAccountNotFound),
+		errors.Is(err, quizservice.ErrWordNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, quizservice.ErrSessionFinished),
+		errors.Is(err, quizservice.ErrSessionRunning),
+		errors.Is(err, quizservice.ErrQuizExists),
+		errors.Is(err, quizservice.ErrStatusConflict),
+		errors.Is(err, quizservice.ErrInvalidTransition),
+		errors.Is(err, quizservice.ErrQuizNotOpen),
+		errors.Is(err, quizservice.ErrQuizNotStarted),
+		errors.Is(err, quizservice.ErrQuizLive),
+		errors.Is(err, quizservice.ErrPracticeSession),
+		errors.Is(err, quizservice.ErrSessionNotStarted),
+		errors.Is(err, quizservice.ErrSessionPaused),
+		errors.Is(err, quizservice.ErrSessionPhase),
+		errors.Is(err, quizservice.ErrQuestionNotCurrent),
+		errors.Is(err, quizservice.ErrQuestionClosed),
+		errors.Is(err, quizservice.ErrPlayerMuted),
+		errors.Is(err, quizservice.ErrSessionFull),
+		errors.Is(err, quizservice.ErrSessionStarted),
+		errors.Is(err, quizservice.ErrAlreadyAnswered),
+		errors.Is(err, quizservice.ErrWordExists),
+		errors.Is(err, quizservice.ErrWordInUse),
+		errors.Is(err, quizservice.ErrPoolUnsatisfiable),
+		errors.Is(err, quizservice.ErrNothingToReview),
+		errors.Is(err, quizservice.ErrUsernameTaken),
+		errors.Is(err, quizservice.ErrNotGuest):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, quizservice.ErrInvalidSchedule),
+		errors.Is(err, quizservice.ErrUnknownHostAction),
+		errors.Is(err, quizservice.ErrInvalidSessionSettings),
+		errors.Is(err, quizservice.ErrInvalidWord),
+		errors.Is(err, quizservice.ErrNotEnoughWords),
+		errors.Is(err, quizservice.ErrInvalidOptions),
+		errors.Is(err, quizservice.ErrInvalidAccount):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, quizservice.ErrNotSignedIn),
+		errors.Is(err, quizservice.ErrInvalidCredentials):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, quizservice.ErrTooManyAttempts),
+		errors.Is(err, quizservice.ErrTooManySignIns):
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+	case errors.Is(err, quizservice.ErrSelfReview),
+		errors.Is(err, quizservice.ErrNotHost),
+		errors.Is(err, quizservice.ErrNotInSession),
+		errors.Is(err, quizservice.ErrOtherPlayer),
+		errors.Is(err, quizservice.ErrGuestAccount),
+		errors.Is(err, quizservice.ErrNotEditor),
+		errors.Is(err, quizservice.ErrJoinByCode),
+		errors.Is(err, quizservice.ErrKicked):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
@@ -1,16 +1,24 @@
 package quizhandler
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
 	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/broadcast"
+	"wordwizardry/internal/services/quizservice"
 )
 
 func (h *QuizHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	sessionID := r.URL.Query().Get("session_id")
 	playerID := r.URL.Query().Get("player_id")
 
+	if hostKey := r.URL.Query().Get("host_key"); hostKey != "" && sessionID != "" {
+		h.handleHostWebSocket(w, r, sessionID, hostKey)
+		return
+	}
+
 	if sessionID == "" || playerID == "" {
 		http.Error(w, "Missing session_id or player_id", http.StatusBadRequest)
 		return
@@ -31,10 +39,10 @@ func (h *QuizHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Handle WebSocket connection
-	err = h.hub.HandleWebSocket(w, r)
+	// Handle WebSocket connection, replaying anything missed since last_seq
+	resume, err := h.hub.HandleWebSocket(w, r, playerID)
 	if err != nil {
-		http.Error(w, "Failed to upgrade connection", http.StatusInternalServerError)
+		writeWebSocketError(w, err)
 		return
 	}
 
@@ -45,6 +53,7 @@ func (h *QuizHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 			"room_info": map[string]interface{}{
 				"player_count": len(session.Players),
 				"leaderboard":  session.Players,
+				"seq":          resume.Seq,
 			},
 			"player": map[string]interface{}{
 				"id":       playerID,
@@ -53,9 +62,14 @@ func (h *QuizHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
+	joinType := "player_connected"
+	if resume.Resumed {
+		joinType = "player_reconnected"
+	}
+
 	// Broadcast player joined to room
 	joinMsg := models.WSMessage{
-		Type: "player_connected",
+		Type: joinType,
 		Data: map[string]interface{}{
 			"count": len(session.Players),
 			"player": map[string]interface{}{
@@ -71,8 +85,68 @@ func (h *QuizHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Failed to send welcome message: %v", err)
 	}
 
+	// Too far behind to replay, send the current state instead
+	if resume.Gap {
+		snapshotMsg := models.WSMessage{
+			Type: "state_snapshot",
+			Data: map[string]interface{}{
+				"seq":          resume.Seq,
+				"player_count": len(session.Players),
+				"leaderboard":  session.Players,
+			},
+		}
+
+		err = h.hub.SendToPlayer(r.Context(), sessionID, playerID, snapshotMsg)
+		if err != nil {
+			log.Printf("Failed to send state snapshot: %v", err)
+		}
+	}
+
 	err = h.hub.BroadcastToRoom(r.Context(), sessionID, joinMsg)
 	if err != nil {
 		log.Printf("Failed to broadcast player joined: %v", err)
 	}
 }
+
+// handleHostWebSocket connects the host to the session room. The host gets
+// every room broadcast and sends host commands over the connection.
+func (h *QuizHandler) handleHostWebSocket(w http.ResponseWriter, r *http.Request, sessionID, hostKey string) {
+	session, err := h.quizService.AuthorizeHost(r.Context(), sessionID, hostKey)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	resume, err := h.hub.HandleWebSocket(w, r, quizservice.HostClientID)
+	if err != nil {
+		writeWebSocketError(w, err)
+		return
+	}
+
+	welcomeMsg := models.WSMessage{
+		Type: "host_joined",
+		Data: map[string]interface{}{
+			"seq":              resume.Seq,
+			"phase":            session.Phase,
+			"join_code":        session.JoinCode,
+			"current_question": session.CurrentQuestion,
+			"questions":        len(session.Questions),
+			"leaderboard":      session.Players,
+		},
+	}
+
+	if err := h.hub.SendToPlayer(r.Context(), sessionID, quizservice.HostClientID, welcomeMsg); err != nil {
+		log.Printf("Failed to send host welcome message: %v", err)
+	}
+}
+
+// writeWebSocketError reports a connection that could not be set up. Bad
+// parameters are the client's fault, anything else is ours.
+func writeWebSocketError(w http.ResponseWriter, err error) {
+	if errors.Is(err, broadcast.ErrInvalidLastSeq) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Error(w, "Failed to upgrade connection", http.StatusInternalServerError)
+}
@@ -0,0 +1,32 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// PlayerRating returns the Elo rating of a player
+func (h *QuizHandler) PlayerRating(w http.ResponseWriter, r *http.Request) {
+	rating, err := h.quizService.PlayerRating(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rating)
+}
+
+// QuestionRatings returns how hard the questions of a quiz turned out to be
+func (h *QuizHandler) QuestionRatings(w http.ResponseWriter, r *http.Request) {
+	ratings, err := h.quizService.QuestionRatings(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"questions": ratings,
+	})
+}
@@ -0,0 +1,42 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"wordwizardry/internal/services/quizservice"
+)
+
+// EndSession finishes a running session and saves the final results. Hosts
+// send their key in the header, players of other sessions their token.
+func (h *QuizHandler) EndSession(w http.ResponseWriter, r *http.Request) {
+	req := quizservice.EndSessionRequest{
+		HostKey: r.Header.Get(hostKeyHeader),
+		Token:   r.Header.Get(playerTokenHeader),
+	}
+
+	results, err := h.quizService.RequestEndSession(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"results": results,
+	})
+}
+
+// QuizResults returns the saved results of every finished session of a quiz
+func (h *QuizHandler) QuizResults(w http.ResponseWriter, r *http.Request) {
+	results, err := h.quizService.QuizResults(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"results": results,
+	})
+}
@@ -0,0 +1,73 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"wordwizardry/internal/services/quizservice"
+)
+
+// ReviewQueue returns the words a player is due to review with the quiz
+// assembled from them, shaped by the count, options and seed parameters
+func (h *QuizHandler) ReviewQueue(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.ReviewRequest
+	query := r.URL.Query()
+
+	for name, target := range map[string]*int{"count": &req.Count, "options": &req.Options} {
+		if v := query.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				http.Error(w, "Invalid "+name, http.StatusBadRequest)
+				return
+			}
+			*target = n
+		}
+	}
+
+	if v := query.Get("seed"); v != "" {
+		seed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid seed", http.StatusBadRequest)
+			return
+		}
+		req.Seed = seed
+	}
+
+	req.Token = r.Header.Get(playerTokenHeader)
+
+	resp, err := h.quizService.ReviewQueue(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// StartReview starts a practice session of the player's review quiz
+func (h *QuizHandler) StartReview(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.ReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	req.Token = r.Header.Get(playerTokenHeader)
+
+	if req.Username == "" && req.Token == "" {
+		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.quizService.StartReview(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
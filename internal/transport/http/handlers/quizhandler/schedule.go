@@ -0,0 +1,61 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"wordwizardry/internal/services/quizservice"
+)
+
+// ScheduleQuiz sets the open, close and live start times of a quiz
+func (h *QuizHandler) ScheduleQuiz(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.ScheduleQuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	schedule, err := h.quizService.ScheduleQuiz(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+func (h *QuizHandler) QuizSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, err := h.quizService.QuizSchedule(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+// UnscheduleQuiz removes the schedule so the quiz is always open
+func (h *QuizHandler) UnscheduleQuiz(w http.ResponseWriter, r *http.Request) {
+	if err := h.quizService.UnscheduleQuiz(r.Context(), r.PathValue("id")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// QuizSchedules lists every schedule, soonest opening first
+func (h *QuizHandler) QuizSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules, err := h.quizService.QuizSchedules(r.Context())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"schedules": schedules,
+	})
+}
@@ -0,0 +1,45 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// SessionEvents returns the ordered event log of a session for audits. Logs
+// of running sessions need the host key.
+func (h *QuizHandler) SessionEvents(w http.ResponseWriter, r *http.Request) {
+	events, err := h.quizService.SessionEvents(r.Context(), r.PathValue("id"), r.Header.Get(hostKeyHeader))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"events": events,
+	})
+}
+
+// ReplaySession folds the event log up to the optional seq query parameter,
+// with the same access as SessionEvents
+func (h *QuizHandler) ReplaySession(w http.ResponseWriter, r *http.Request) {
+	var seq int64
+	if v := r.URL.Query().Get("seq"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed < 0 {
+			http.Error(w, "Invalid seq", http.StatusBadRequest)
+			return
+		}
+		seq = parsed
+	}
+
+	resp, err := h.quizService.ReplaySession(r.Context(), r.PathValue("id"), r.Header.Get(hostKeyHeader), seq)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,97 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"wordwizardry/internal/services/quizservice"
+)
+
+// CreateSession starts a new session of a quiz that players join by its ID
+func (h *QuizHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.CreateSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.QuizID == "" {
+		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		return
+	}
+
+	session, err := h.quizService.CreateSession(r.Context(), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(session)
+}
+
+// ActiveSessions lists the sessions of a quiz that have not finished
+func (h *QuizHandler) ActiveSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.quizService.ActiveSessions(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"sessions": sessions,
+	})
+}
+
+// SetReady toggles a player's ready-check while the session is in the lobby
+func (h *QuizHandler) SetReady(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PlayerID string `json:"player_id"`
+		Ready    bool   `json:"ready"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.PlayerID == "" {
+		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		return
+	}
+
+	err := h.quizService.SetReady(r.Context(), r.PathValue("id"), req.PlayerID, r.Header.Get(playerTokenHeader), req.Ready)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// OpenQuestion starts the clock on a question for a player of a session that
+// is not hosted, from {"player_id": "..."}
+func (h *QuizHandler) OpenQuestion(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PlayerID string `json:"player_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.PlayerID == "" {
+		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		return
+	}
+
+	err := h.quizService.OpenQuestion(r.Context(), r.PathValue("id"), req.PlayerID, r.PathValue("question_id"),
+		r.Header.Get(playerTokenHeader))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -13,8 +13,60 @@ func SetupQuizRoutes(
 	hub *broadcast.WebSocketHub,
 ) {
 	handler := NewQuizHandler(quizService, hub)
+	hub.SetMessageHandler(handler.HandleClientMessage)
 
-	mux.HandleFunc("/api/quiz/join", handler.JoinQuiz)
-	mux.HandleFunc("/api/quiz/submit-answer", handler.SubmitAnswer)
+	mux.HandleFunc("POST /api/quiz/join", handler.JoinQuiz)
+	mux.HandleFunc("POST /api/quiz/submit-answer", handler.SubmitAnswer)
+	mux.HandleFunc("POST /api/join/{code}", handler.JoinByCode)
+	mux.HandleFunc("POST /api/practice", handler.StartPractice)
+	mux.HandleFunc("POST /api/players", handler.Register)
+	mux.HandleFunc("POST /api/players/guest", handler.CreateGuest)
+	mux.HandleFunc("POST /api/players/login", handler.Login)
+	mux.HandleFunc("POST /api/players/logout", handler.Logout)
+	mux.HandleFunc("POST /api/players/upgrade", handler.UpgradeGuest)
+	mux.HandleFunc("GET /api/players/me", handler.Me)
+	mux.HandleFunc("GET /api/players/{id}/profile", handler.Profile)
+	mux.HandleFunc("GET /api/players/{id}/review", handler.ReviewQueue)
+	mux.HandleFunc("POST /api/players/{id}/review", handler.StartReview)
+	mux.HandleFunc("GET /api/players/{id}/rating", handler.PlayerRating)
 	mux.HandleFunc("/ws", handler.HandleWebSocket)
+
+	mux.HandleFunc("POST /api/sessions", handler.CreateSession)
+	mux.HandleFunc("GET /api/sessions/{id}/events", handler.SessionEvents)
+	mux.HandleFunc("GET /api/sessions/{id}/replay", handler.ReplaySession)
+	mux.HandleFunc("POST /api/sessions/{id}/ready", handler.SetReady)
+	mux.HandleFunc("POST /api/sessions/{id}/questions/{question_id}/open", handler.OpenQuestion)
+	mux.HandleFunc("POST /api/sessions/{id}/end", handler.EndSession)
+	mux.HandleFunc("POST /api/sessions/{id}/host/{action}", handler.HostCommand)
+	mux.HandleFunc("GET /api/sessions/{id}/export", handler.ExportSession)
+
+	mux.HandleFunc("GET /api/schedules", handler.QuizSchedules)
+
+	mux.HandleFunc("GET /api/words", handler.Words)
+	mux.HandleFunc("POST /api/words", handler.CreateWord)
+	mux.HandleFunc("GET /api/words/{id}", handler.Word)
+	mux.HandleFunc("PUT /api/words/{id}", handler.UpdateWord)
+	mux.HandleFunc("DELETE /api/words/{id}", handler.DeleteWord)
+	mux.HandleFunc("GET /api/words/{id}/stats", handler.WordStats)
+
+	mux.HandleFunc("POST /api/quiz/import", handler.ImportQuiz)
+	mux.HandleFunc("POST /api/quiz/lint", handler.LintQuizContent)
+	mux.HandleFunc("POST /api/quiz/generate", handler.GenerateQuiz)
+	mux.HandleFunc("GET /api/quiz/{id}", handler.GetQuiz)
+	mux.HandleFunc("PUT /api/quiz/{id}", handler.UpdateQuiz)
+	mux.HandleFunc("GET /api/quiz/{id}/versions", handler.QuizVersions)
+	mux.HandleFunc("GET /api/quiz/{id}/versions/{version}", handler.QuizVersion)
+	mux.HandleFunc("GET /api/quiz/{id}/diff", handler.DiffQuizVersions)
+	mux.HandleFunc("POST /api/quiz/{id}/rollback", handler.RollbackQuiz)
+	mux.HandleFunc("POST /api/quiz/{id}/status", handler.ChangeQuizStatus)
+	mux.HandleFunc("POST /api/quiz/{id}/review", handler.ReviewQuiz)
+	mux.HandleFunc("GET /api/quiz/{id}/lint", handler.LintQuiz)
+	mux.HandleFunc("GET /api/quiz/{id}/schedule", handler.QuizSchedule)
+	mux.HandleFunc("PUT /api/quiz/{id}/schedule", handler.ScheduleQuiz)
+	mux.HandleFunc("DELETE /api/quiz/{id}/schedule", handler.UnscheduleQuiz)
+	mux.HandleFunc("GET /api/quiz/{id}/sessions", handler.ActiveSessions)
+	mux.HandleFunc("GET /api/quiz/{id}/results", handler.QuizResults)
+	mux.HandleFunc("GET /api/quiz/{id}/practice-results", handler.PracticeResults)
+	mux.HandleFunc("GET /api/quiz/{id}/ratings", handler.QuestionRatings)
+	mux.HandleFunc("GET /api/quiz/{id}/export", handler.ExportQuiz)
 }
@@ -0,0 +1,111 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"wordwizardry/internal/services/quizservice"
+)
+
+// GetQuiz returns the latest version of a quiz, with the answer key for
+// editors
+func (h *QuizHandler) GetQuiz(w http.ResponseWriter, r *http.Request) {
+	version, err := h.quizService.Quiz(r.Context(), r.PathValue("id"), r.Header.Get(playerTokenHeader))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(version)
+}
+
+// UpdateQuiz writes a new version of a quiz
+func (h *QuizHandler) UpdateQuiz(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.UpdateQuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	version, err := h.quizService.UpdateQuiz(r.Context(), r.PathValue("id"), r.Header.Get(playerTokenHeader), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(version)
+}
+
+// QuizVersions returns the version history of a quiz, oldest first
+func (h *QuizHandler) QuizVersions(w http.ResponseWriter, r *http.Request) {
+	versions, err := h.quizService.QuizVersions(r.Context(), r.PathValue("id"), r.Header.Get(playerTokenHeader))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"versions": versions,
+	})
+}
+
+// QuizVersion returns a single version of a quiz
+func (h *QuizHandler) QuizVersion(w http.ResponseWriter, r *http.Request) {
+	number, err := strconv.Atoi(r.PathValue("version"))
+	if err != nil {
+		http.Error(w, "Invalid version", http.StatusBadRequest)
+		return
+	}
+
+	version, err := h.quizService.QuizVersion(r.Context(), r.PathValue("id"), number, r.Header.Get(playerTokenHeader))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(version)
+}
+
+// DiffQuizVersions compares the from and to versions given as query parameters
+func (h *QuizHandler) DiffQuizVersions(w http.ResponseWriter, r *http.Request) {
+	from, errFrom := strconv.Atoi(r.URL.Query().Get("from"))
+	to, errTo := strconv.Atoi(r.URL.Query().Get("to"))
+	if errFrom != nil || errTo != nil {
+		http.Error(w, "Both from and to versions are required", http.StatusBadRequest)
+		return
+	}
+
+	diff, err := h.quizService.DiffQuizVersions(r.Context(), r.PathValue("id"), r.Header.Get(playerTokenHeader), from, to)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diff)
+}
+
+// RollbackQuiz restores an older version as the latest one
+func (h *QuizHandler) RollbackQuiz(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Version int `json:"version"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Version == 0 {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	version, err := h.quizService.RollbackQuiz(r.Context(), r.PathValue("id"), r.Header.Get(playerTokenHeader), req.Version)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(version)
+}
@@ -0,0 +1,99 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"wordwizardry/internal/pkg/models"
+	"wordwizardry/internal/services/quizservice"
+	"wordwizardry/internal/services/quizservice/wordbank"
+)
+
+// Words lists the word bank, filtered by the q, pos, level and tag query
+// parameters
+func (h *QuizHandler) Words(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	words, err := h.quizService.Words(r.Context(), wordbank.WordFilter{
+		Query:        query.Get("q"),
+		PartOfSpeech: query.Get("pos"),
+		Level:        models.CEFRLevel(query.Get("level")),
+		Tag:          query.Get("tag"),
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"words": words,
+	})
+}
+
+func (h *QuizHandler) CreateWord(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.WordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	word, err := h.quizService.CreateWord(r.Context(), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(word)
+}
+
+func (h *QuizHandler) Word(w http.ResponseWriter, r *http.Request) {
+	word, err := h.quizService.Word(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(word)
+}
+
+func (h *QuizHandler) UpdateWord(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.WordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	word, err := h.quizService.UpdateWord(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(word)
+}
+
+// DeleteWord removes a word that no quiz asks about
+func (h *QuizHandler) DeleteWord(w http.ResponseWriter, r *http.Request) {
+	if err := h.quizService.DeleteWord(r.Context(), r.PathValue("id")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// WordStats returns how players did on the questions asking about a word
+func (h *QuizHandler) WordStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.quizService.WordStats(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
@@ -0,0 +1,49 @@
+package quizhandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"wordwizardry/internal/services/quizservice"
+)
+
+// ChangeQuizStatus moves a quiz version to another workflow status
+func (h *QuizHandler) ChangeQuizStatus(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.ChangeQuizStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Status == "" {
+		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		return
+	}
+
+	version, err := h.quizService.ChangeQuizStatus(r.Context(), r.PathValue("id"), r.Header.Get(playerTokenHeader), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(version)
+}
+
+// ReviewQuiz approves or rejects a quiz version that is in review
+func (h *QuizHandler) ReviewQuiz(w http.ResponseWriter, r *http.Request) {
+	var req quizservice.ReviewQuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	version, err := h.quizService.ReviewQuiz(r.Context(), r.PathValue("id"), r.Header.Get(playerTokenHeader), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(version)
+}
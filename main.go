@@ -7,9 +7,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"wordwizardry/internal/cli"
+
 	"wordwizardry/internal/transport/http/handlers/healthcheckhandler"
 	"wordwizardry/internal/transport/http/handlers/publichandler"
 	"wordwizardry/internal/transport/http/handlers/quizhandler"
@@ -17,11 +20,25 @@ import (
 	"wordwizardry/internal/services/broadcast"
 
 	"wordwizardry/internal/services/quizservice"
+	redisplayerrepository "wordwizardry/internal/services/quizservice/players/redis"
 	inmemory "wordwizardry/internal/services/quizservice/quizrepositories/inmemory"
+	"wordwizardry/internal/services/quizservice/quizrepositories/linted"
+	redisratingstore "wordwizardry/internal/services/quizservice/ratings/redis"
+	redisreviewstore "wordwizardry/internal/services/quizservice/reviews/redis"
+	redisschedulestore "wordwizardry/internal/services/quizservice/schedules/redis"
 	redissessionmanager "wordwizardry/internal/services/quizservice/sessions/redis"
+	inmemorywordbank "wordwizardry/internal/services/quizservice/wordbank/inmemory"
 )
 
 func main() {
+	// Any argument selects a CLI subcommand instead of starting the server
+	if len(os.Args) > 1 {
+		if err := cli.Run(os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	if err := run(); err != nil {
 		log.Fatal(err)
 	}
@@ -38,19 +55,55 @@ func run() error {
 		return err
 	}
 
+	scheduleStore, err := redisschedulestore.NewRedisScheduleStore(redisURL)
+	if err != nil {
+		return err
+	}
+
+	reviewStore, err := redisreviewstore.NewRedisReviewStore(redisURL)
+	if err != nil {
+		return err
+	}
+
+	ratingStore, err := redisratingstore.NewRedisRatingStore(redisURL)
+	if err != nil {
+		return err
+	}
+
+	playerRepository, err := redisplayerrepository.NewRedisPlayerRepository(redisURL)
+	if err != nil {
+		return err
+	}
+
 	hub := broadcast.NewWebSocketHub()
 	go hub.Run()
 
-	quizReader := inmemory.NewQuizRepository()
-	quizWriter := inmemory.NewQuizRepository()
+	// Reads must see what was written, so both sides share one repository
+	quizRepository := inmemory.NewQuizRepository()
+
+	// Content is linted on every create and update
+	quizWriter := linted.NewQuizWriter(quizRepository, quizRepository)
 
 	quizService := quizservice.NewQuizService(
-		quizReader,
+		quizRepository,
 		quizWriter,
 		sessionManager,
+		scheduleStore,
+		inmemorywordbank.NewWordBank(),
+		reviewStore,
+		ratingStore,
+		playerRepository,
 		hub,
 	)
 
+	// Accounts are listed by ID, usernames could be taken by someone else
+	// before the editor registers
+	quizService.SetEditors(editorIDs(os.Getenv("EDITORS")))
+
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go quizService.RunScheduler(schedulerCtx)
+
 	mux := http.NewServeMux()
 
 	healthcheckhandler.SetupHealthCheckRoutes(mux)
@@ -99,3 +152,14 @@ func run() error {
 
 	return nil
 }
+
+// editorIDs splits a comma separated list of account IDs
+func editorIDs(list string) []string {
+	var ids []string
+	for _, id := range strings.Split(list, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}